@@ -35,4 +35,32 @@ const (
 
 	// IntervalNotConfiguredReason signals that the interval is missing.
 	IntervalNotConfiguredReason string = "IntervalNotConfigured"
+
+	// MinimumAgeNotReachedReason signals that none of the tags has been
+	// observed for longer than the minimum age.
+	MinimumAgeNotReachedReason string = "MinimumAgeNotReached"
+
+	// TagCollisionReason signals that several tags share the value extracted
+	// from the latest tag, and the collision strategy is set to fail.
+	TagCollisionReason string = "TagCollision"
+
+	// DowngradePreventedReason signals that the latest tag was kept, as the
+	// newly elected tag ranks lower than it.
+	DowngradePreventedReason string = "DowngradePrevented"
+
+	// LatestTagRemovedReason signals that the latest tag is not listed in the
+	// image repository anymore.
+	LatestTagRemovedReason string = "LatestTagRemoved"
+
+	// AwaitingApprovalReason signals that the newly elected image is pending
+	// approval.
+	AwaitingApprovalReason string = "AwaitingApproval"
+
+	// OutsidePromotionWindowReason signals that the newly elected image is
+	// held until the next promotion window opens.
+	OutsidePromotionWindowReason string = "OutsidePromotionWindow"
+
+	// PinnedReason signals that the latest image is pinned, regardless of
+	// the policy.
+	PinnedReason string = "Pinned"
 )
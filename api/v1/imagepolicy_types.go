@@ -25,10 +25,18 @@ import (
 
 const ImagePolicyKind = "ImagePolicy"
 
+// ApprovedRefAnnotation is the annotation approving the image pending in
+// .status.pendingRef, when .spec.requireApproval is set. Its value is the
+// digest or the tag@digest of the approved image, or its tag when the digest
+// of the pending image is not known.
+const ApprovedRefAnnotation = "image.toolkit.fluxcd.io/approved-ref"
+
 // ImagePolicySpec defines the parameters for calculating the
 // ImagePolicy.
 // +kubebuilder:validation:XValidation:rule="!has(self.interval) || (has(self.digestReflectionPolicy) && self.digestReflectionPolicy == 'Always')", message="spec.interval is only accepted when spec.digestReflectionPolicy is set to 'Always'"
 // +kubebuilder:validation:XValidation:rule="has(self.interval) || !has(self.digestReflectionPolicy) || self.digestReflectionPolicy != 'Always'", message="spec.interval must be set when spec.digestReflectionPolicy is set to 'Always'"
+// +kubebuilder:validation:XValidation:rule="!has(self.policy.floatingTag) || (has(self.digestReflectionPolicy) && self.digestReflectionPolicy == 'Always')", message="spec.digestReflectionPolicy must be set to 'Always' when spec.policy.floatingTag is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.platforms) || (has(self.digestReflectionPolicy) && self.digestReflectionPolicy != 'Never')", message="spec.digestReflectionPolicy must not be set to 'Never' when spec.platforms is set"
 type ImagePolicySpec struct {
 	// ImageRepositoryRef points at the object specifying the image
 	// being scanned
@@ -38,6 +46,27 @@ type ImagePolicySpec struct {
 	// selecting the most recent image
 	// +required
 	Policy ImagePolicyChoice `json:"policy"`
+	// MirrorRepositoryRefs points at other ImageRepositories mirroring the
+	// images of the referenced one. The latest tag is elected from the union
+	// of their tags, and reported with the image of the first repository
+	// listing it, starting with the referenced one.
+	// +optional
+	MirrorRepositoryRefs []meta.NamespacedObjectReference `json:"mirrorRepositoryRefs,omitempty"`
+	// LockstepRepositoryRefs points at other ImageRepositories which must
+	// list the elected tag too, so that the images of several components
+	// move together to a version available for all of them.
+	// +optional
+	LockstepRepositoryRefs []meta.NamespacedObjectReference `json:"lockstepRepositoryRefs,omitempty"`
+	// CeilingPolicyRef points at another ImagePolicy whose latest tag is the
+	// upper bound of the tags this policy elects from, compared according to
+	// this policy.
+	// +optional
+	CeilingPolicyRef *meta.NamespacedObjectReference `json:"ceilingPolicyRef,omitempty"`
+	// FloorPolicyRef points at another ImagePolicy whose latest tag is the
+	// lower bound of the tags this policy elects from, compared according to
+	// this policy.
+	// +optional
+	FloorPolicyRef *meta.NamespacedObjectReference `json:"floorPolicyRef,omitempty"`
 	// FilterTags enables filtering for only a subset of tags based on a set of
 	// rules. If no rules are provided, all the tags from the repository will be
 	// ordered and compared.
@@ -67,12 +96,145 @@ type ImagePolicySpec struct {
 	// +optional
 	Interval *metav1.Duration `json:"interval,omitempty"`
 
+	// Platforms enables reflecting the digest of each platform manifest of
+	// the latest image in .status.platformDigests, when it is a
+	// multi-platform image index. It requires .spec.digestReflectionPolicy
+	// to be set to IfNotPresent or Always.
+	// +optional
+	Platforms *PlatformsSpec `json:"platforms,omitempty"`
+
+	// MinimumAge is the minimum length of time a tag must have been observed
+	// in the image repository before it can be elected as the latest image.
+	// A newer tag which has not reached the minimum age yet is reported in
+	// .status.soakingRef, and the policy is reconciled again once it does.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ms|s|m|h))+$"
+	// +optional
+	MinimumAge *metav1.Duration `json:"minimumAge,omitempty"`
+
+	// Candidates enables reporting the top ranked tags according to the
+	// policy in .status.candidates.
+	// +optional
+	Candidates *CandidatesSpec `json:"candidates,omitempty"`
+
+	// LatestTagRemovalPolicy governs what happens when the tag in
+	// .status.latestRef is not listed in the image repository anymore.
+	//
+	// Keep: The latest image is kept until a higher ranked tag is elected, and a
+	// warning is reported.
+	//
+	// Fallback: The next best tag is elected, and a warning is reported.
+	//
+	// Fail: The ImagePolicy is marked as not ready.
+	//
+	// Default: Fallback.
+	// +kubebuilder:default:=Fallback
+	// +optional
+	LatestTagRemovalPolicy LatestTagRemovalPolicy `json:"latestTagRemovalPolicy,omitempty"`
+
+	// PreventDowngrade keeps the current .status.latestRef when the newly
+	// elected tag ranks lower than it according to the policy, e.g. after
+	// the tag was deleted from the registry.
+	// +optional
+	PreventDowngrade bool `json:"preventDowngrade,omitempty"`
+
+	// RequireApproval holds newly elected images in .status.pendingRef until
+	// the image.toolkit.fluxcd.io/approved-ref annotation is set to their
+	// tag, digest or tag@digest, before promoting them to .status.latestRef.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+
+	// Pin freezes .status.latestRef to the given tag, or tag@digest,
+	// regardless of the policy, which keeps being applied to report the
+	// image it would elect in .status.unpinnedRef. The tag must be listed in
+	// the image repository, and is pinned even if the policy fails to elect
+	// an image. Pinned images are not held for approval or promotion windows.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}(@[a-z0-9]+([+._-][a-z0-9]+)*:[a-zA-Z0-9=_-]+)?$"
+	// +optional
+	Pin string `json:"pin,omitempty"`
+
+	// PromotionWindows restricts the updates of .status.latestRef to the
+	// given weekly time windows. Outside of them, a newly elected image is
+	// held in .status.pendingRef and promoted once the next window opens.
+	// Images are promoted at any time when empty.
+	// +optional
+	PromotionWindows []PromotionWindow `json:"promotionWindows,omitempty"`
+
 	// This flag tells the controller to suspend subsequent policy reconciliations.
 	// It does not apply to already started reconciliations. Defaults to false.
 	// +optional
 	Suspend bool `json:"suspend,omitempty"`
 }
 
+// PromotionWindow defines a time range recurring on some days of the week.
+type PromotionWindow struct {
+	// Days are the days of the week the window opens on. Defaults to every
+	// day of the week.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+	// Start is the time of day the window opens at, in the HH:MM format.
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	// +required
+	Start string `json:"start"`
+	// End is the time of day the window closes at, in the HH:MM format. A
+	// window ending at or before its start closes on the next day.
+	// +kubebuilder:validation:Pattern="^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$"
+	// +required
+	End string `json:"end"`
+	// TimeZone is the IANA name of the time zone of the start and end
+	// times, e.g. Europe/Paris. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// CandidatesSpec specifies how many of the top ranked tags are reported.
+type CandidatesSpec struct {
+	// Count is the number of top ranked tags to report, starting with the
+	// latest one.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +required
+	Count int `json:"count"`
+	// ReflectDigests enables fetching the digest of each candidate from the
+	// registry. The digests already reflected are reused, unless the digest
+	// reflection policy is Always. Defaults to false.
+	// +optional
+	ReflectDigests bool `json:"reflectDigests,omitempty"`
+}
+
+// PlatformsSpec specifies how the platform manifests of the latest image are
+// reflected.
+type PlatformsSpec struct {
+	// Select is the platform, in the os/arch[/variant] format, e.g.
+	// linux/arm64, whose manifest digest is set in .status.latestRef.digest
+	// instead of the digest of the image index. The default variant of the
+	// architecture may be omitted, and a platform without variant matches
+	// any variant. The digest of images which are not an index is set as is.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$"
+	// +optional
+	Select string `json:"select,omitempty"`
+}
+
+// LatestTagRemovalPolicy describes what happens when the latest tag is removed
+// from the image repository.
+// +kubebuilder:validation:Enum=Keep;Fallback;Fail
+type LatestTagRemovalPolicy string
+
+const (
+	// LatestTagRemovalKeep means that the removed tag is kept as the latest
+	// image.
+	LatestTagRemovalKeep LatestTagRemovalPolicy = "Keep"
+	// LatestTagRemovalFallback means that the next best tag is elected.
+	LatestTagRemovalFallback LatestTagRemovalPolicy = "Fallback"
+	// LatestTagRemovalFail means that the policy fails until the tag is
+	// listed again or the spec changes.
+	LatestTagRemovalFail LatestTagRemovalPolicy = "Fail"
+)
+
 // ReflectionPolicy describes a policy for if/when to reflect a value from the registry in a certain resource field.
 // +kubebuilder:validation:Enum=Always;IfNotPresent;Never
 type ReflectionPolicy string
@@ -101,6 +263,24 @@ type ImagePolicyChoice struct {
 	// Numerical set of rules to use for numerical ordering of the tags.
 	// +optional
 	Numerical *NumericalPolicy `json:"numerical,omitempty"`
+	// CalVer gives a calendar versioning format and range to check against
+	// the tags available.
+	// +optional
+	CalVer *CalVerPolicy `json:"calver,omitempty"`
+	// CreationTime orders the tags by the creation time of the images they
+	// point to, selecting the most recently built image.
+	// +optional
+	CreationTime *CreationTimePolicy `json:"creationTime,omitempty"`
+	// Version orders the tags as dotted versions with any number of numeric
+	// components, e.g. 10.0.17763.5122.
+	// +optional
+	Version *VersionPolicy `json:"version,omitempty"`
+	// FloatingTag follows a single tag whose image is updated in place, e.g.
+	// stable, reflecting its current digest and recording the observed ones
+	// in .status.digestHistory. It requires .spec.digestReflectionPolicy to
+	// be set to Always.
+	// +optional
+	FloatingTag *FloatingTagPolicy `json:"floatingTag,omitempty"`
 }
 
 // SemVerPolicy specifies a semantic version policy.
@@ -109,6 +289,29 @@ type SemVerPolicy struct {
 	// version within the range that's a tag yields the latest image.
 	// +required
 	Range string `json:"range"`
+	// Tracks enables electing the latest version of each release track
+	// within the range, in addition to the overall latest version. The
+	// results are reported in .status.tracks, keyed by track. With 'minor',
+	// the newest patch of every minor version is elected, keyed by
+	// '<major>.<minor>'. With 'major', the newest version of every major
+	// version is elected, keyed by '<major>'.
+	// +kubebuilder:validation:Enum=major;minor
+	// +optional
+	Tracks string `json:"tracks,omitempty"`
+	// Variant restricts the tags to the ones ending with the given suffix,
+	// separated from the version by a dash, e.g. 'alpine' for
+	// '1.4.0-alpine'. The suffix is stripped before parsing the version, so
+	// that it is not considered as a prerelease.
+	// +kubebuilder:validation:Pattern="^[0-9A-Za-z.-]+$"
+	// +optional
+	Variant string `json:"variant,omitempty"`
+	// IncludePrereleases gives the prerelease channels to consider, e.g. 'rc'
+	// for '2.0.0-rc.3' or 'beta' for '2.0.0-beta1'. When set, the prerelease
+	// versions of these channels are checked against the range as their
+	// release version, and the prerelease versions of other channels are
+	// ignored.
+	// +optional
+	IncludePrereleases []string `json:"includePrereleases,omitempty"`
 }
 
 // AlphabeticalPolicy specifies a alphabetical ordering policy.
@@ -120,6 +323,69 @@ type AlphabeticalPolicy struct {
 	// +kubebuilder:validation:Enum=asc;desc
 	// +optional
 	Order string `json:"order,omitempty"`
+
+	// Collation specifies how the tags are compared. With bytewise, the
+	// tags are compared byte by byte. With natural, the runs of digits
+	// embedded in the tags are compared by their numerical value, so that
+	// build-10 is ordered after build-9.
+	// +kubebuilder:default:="bytewise"
+	// +kubebuilder:validation:Enum=bytewise;natural
+	// +optional
+	Collation string `json:"collation,omitempty"`
+
+	// CaseInsensitive compares the tags regardless of the case of the letters.
+	// +optional
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+}
+
+const (
+	// AlphabeticalCollationBytewise compares the tags byte by byte.
+	AlphabeticalCollationBytewise = "bytewise"
+	// AlphabeticalCollationNatural compares the runs of digits embedded in
+	// the tags numerically.
+	AlphabeticalCollationNatural = "natural"
+)
+
+// VersionPolicy specifies a dotted version ordering policy.
+type VersionPolicy struct {
+	// Range gives a comma or whitespace separated list of constraints the
+	// versions must satisfy, e.g. '>=10.0.17763, <10.0.20348'. Only the
+	// leading components of the versions are compared, as many as each
+	// constraint has. When unset, all the versions are considered.
+	// +optional
+	Range string `json:"range,omitempty"`
+}
+
+// FloatingTagPolicy specifies the tag to follow.
+type FloatingTagPolicy struct {
+	// Tag is the name of the tag to follow.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$"
+	// +required
+	Tag string `json:"tag"`
+	// ResolveVersion enables reporting the versioned tag sharing the digest
+	// of the followed one in .status.latestRef, e.g. 3.4.1 for stable,
+	// instead of the followed tag itself. The followed tag is reported if no
+	// versioned tag shares its digest.
+	// +optional
+	ResolveVersion *VersionResolution `json:"resolveVersion,omitempty"`
+}
+
+// VersionResolution specifies the versioned tags a floating tag is resolved
+// to.
+type VersionResolution struct {
+	// Range gives a semver range the versioned tags must be within. Defaults
+	// to all the stable versions.
+	// +kubebuilder:default:="*"
+	// +optional
+	Range string `json:"range,omitempty"`
+	// MaxTags is the maximum number of versioned tags whose digest is fetched
+	// from the registry at every reconciliation, starting with the highest
+	// version. Defaults to 10.
+	// +kubebuilder:default:=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxTags int `json:"maxTags,omitempty"`
 }
 
 // NumericalPolicy specifies a numerical ordering policy.
@@ -131,6 +397,33 @@ type NumericalPolicy struct {
 	// +kubebuilder:validation:Enum=asc;desc
 	// +optional
 	Order string `json:"order,omitempty"`
+	// SkipInvalid ignores the tags which are not numbers, instead of
+	// failing the policy.
+	// +optional
+	SkipInvalid bool `json:"skipInvalid,omitempty"`
+}
+
+// CalVerPolicy specifies a calendar versioning policy.
+type CalVerPolicy struct {
+	// Format describes the layout of the tags using the conventions from
+	// https://calver.org, e.g. YYYY.0M.MICRO, YY.MM.DD or YY.MM.DD-N. The
+	// supported tokens are YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR,
+	// MICRO and N, a build counter of any number of digits.
+	// Tags which do not match the format are ignored.
+	// +required
+	Format string `json:"format"`
+	// Range gives a set of comparison constraints the tags must satisfy,
+	// e.g. '>=2024.01' or '>=2024.01, <2025'. Constraint versions may be
+	// shorter than the format, in which case only the leading components
+	// are compared.
+	// +optional
+	Range string `json:"range,omitempty"`
+}
+
+// CreationTimePolicy specifies a policy ordering tags by image creation time.
+// The creation time is read from the org.opencontainers.image.created
+// annotation of the image manifest, or else from the image config.
+type CreationTimePolicy struct {
 }
 
 // TagFilter enables filtering tags based on a set of defined rules
@@ -143,6 +436,67 @@ type TagFilter struct {
 	// expression pattern, useful before tag evaluation.
 	// +optional
 	Extract string `json:"extract"`
+	// Include gives a list of regular expression patterns, the tags must
+	// match at least one of them. It is applied before the extraction.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude gives a list of regular expression patterns, the tags matching
+	// any of them are ignored. It is applied before the extraction.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// SortKeys gives the named capture groups of the pattern used to order
+	// the tags which share the same extracted value once the policy has
+	// elected the latest one. Together with the policy they form a tuple
+	// which is compared element by element, e.g. "highest semver, then
+	// highest build number".
+	// +optional
+	SortKeys []TagSortKey `json:"sortKeys,omitempty"`
+	// CollisionStrategy specifies which tag is selected when several tags
+	// share the value extracted from the latest one and no sort keys are
+	// set. Greatest selects the lexicographically greatest tag,
+	// NewestFirstSeen the tag first seen most recently in the image
+	// repository, and Fail fails the policy. When unset, the tag listed
+	// last in the image repository is selected.
+	// +kubebuilder:validation:Enum=Greatest;NewestFirstSeen;Fail
+	// +optional
+	CollisionStrategy string `json:"collisionStrategy,omitempty"`
+	// Expression is a CEL expression evaluated for each tag matching the
+	// pattern, only the tags for which it evaluates to true are kept. The
+	// expression has access to the following variables: tag, value (the
+	// extracted value, or the tag), captures (the capture groups of the
+	// pattern, by name and by index), semver (the major, minor, patch,
+	// prerelease and metadata fields of the value, empty if it is not a
+	// semantic version), firstSeen (the time the tag was first seen in
+	// the repository) and policy (the name and namespace of the policy).
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+// TagSortKey describes how the value of a named capture group is compared.
+type TagSortKey struct {
+	// Group is the name of a capture group in the filter pattern.
+	// +required
+	Group string `json:"group"`
+	// Type specifies how the values of the capture group are compared.
+	// +kubebuilder:validation:Enum=semver;numeric;alphabetical;timestamp
+	// +required
+	Type string `json:"type"`
+	// Order specifies the sorting order of the values. Ascending order
+	// prefers the highest value, and descending order the lowest value.
+	// +kubebuilder:default:="asc"
+	// +kubebuilder:validation:Enum=asc;desc
+	// +optional
+	Order string `json:"order,omitempty"`
+	// Priority determines the position of the key in the tuple. Keys with a
+	// higher priority are compared first, keys with the same priority are
+	// compared in the order they are listed.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+	// Layout is the Go time layout used to parse the values of timestamp
+	// keys, e.g. 2006-01-02T15-04-05Z. When not set, timestamps are parsed
+	// as Unix seconds.
+	// +optional
+	Layout string `json:"layout,omitempty"`
 }
 
 // ImageRef represents an image reference.
@@ -166,6 +520,33 @@ func (in *ImageRef) String() string {
 	return res
 }
 
+// SoakingImageRef represents an image which is not old enough to be elected
+// yet.
+type SoakingImageRef struct {
+	ImageRef `json:",inline"`
+	// EligibleAt is the time at which the image reaches the minimum age.
+	// +required
+	EligibleAt metav1.Time `json:"eligibleAt"`
+}
+
+// ObservedImageRef represents an image observed at some point in time.
+type ObservedImageRef struct {
+	ImageRef `json:",inline"`
+	// ObservedAt is the time at which the image was first observed.
+	// +required
+	ObservedAt metav1.Time `json:"observedAt"`
+}
+
+// PlatformDigest is the digest of a platform manifest of an image index.
+type PlatformDigest struct {
+	// Platform is the platform in the os/arch[/variant] format.
+	// +required
+	Platform string `json:"platform"`
+	// Digest is the digest of the platform manifest.
+	// +required
+	Digest string `json:"digest"`
+}
+
 // ImagePolicyStatus defines the observed state of ImagePolicy
 type ImagePolicyStatus struct {
 	// LatestRef gives the first in the list of images scanned by
@@ -176,6 +557,56 @@ type ImagePolicyStatus struct {
 	// to keep track of the previous and current images.
 	// +optional
 	ObservedPreviousRef *ImageRef `json:"observedPreviousRef,omitempty"`
+	// PendingRef gives the newly elected image awaiting approval, when
+	// .spec.requireApproval is set, or the next promotion window, when
+	// .spec.promotionWindows is set.
+	// +optional
+	PendingRef *ImageRef `json:"pendingRef,omitempty"`
+	// NextPromotionTime gives the time the next promotion window opens at,
+	// when the image in .status.pendingRef is held outside of
+	// .spec.promotionWindows.
+	// +optional
+	NextPromotionTime *metav1.Time `json:"nextPromotionTime,omitempty"`
+	// UnpinnedRef gives the image the policy would elect if .spec.pin was
+	// not set.
+	// +optional
+	UnpinnedRef *ImageRef `json:"unpinnedRef,omitempty"`
+	// DigestHistory gives the digests observed for the followed tag, newest
+	// first, when .spec.policy.floatingTag is set.
+	// +optional
+	DigestHistory []ObservedImageRef `json:"digestHistory,omitempty"`
+	// PlatformDigests gives the digest of each platform manifest of the
+	// image in .status.latestRef, when .spec.platforms is set and the image
+	// is a multi-platform image index.
+	// +optional
+	PlatformDigests []PlatformDigest `json:"platformDigests,omitempty"`
+	// ManifestDigest gives the digest of the manifest the tag of the image in
+	// .status.latestRef points to, when .spec.platforms is set. It is the
+	// digest of the image index for multi-platform images, and the digest of
+	// the image otherwise.
+	// +optional
+	ManifestDigest string `json:"manifestDigest,omitempty"`
+	// SoakingRef gives the image which would be elected if it had been
+	// observed for longer than .spec.minimumAge.
+	// +optional
+	SoakingRef *SoakingImageRef `json:"soakingRef,omitempty"`
+	// Candidates gives the top ranked images according to the policy,
+	// starting with the latest one, when .spec.candidates is set.
+	// +optional
+	Candidates []ImageRef `json:"candidates,omitempty"`
+	// Tracks gives the latest image of each release track, keyed by track,
+	// when .spec.policy.semver.tracks is set.
+	// +optional
+	Tracks map[string]ImageRef `json:"tracks,omitempty"`
+	// ExtractCollision reports the tags sharing the value extracted from the
+	// latest tag, when .spec.filterTags.extract maps several tags to it.
+	// +optional
+	ExtractCollision *ExtractCollision `json:"extractCollision,omitempty"`
+	// RemovedLatestTag gives the latest tag which is not listed in the image
+	// repository anymore, when .spec.latestTagRemovalPolicy is Fallback or
+	// Keep. It is used to notify the removal only once, when first detected.
+	// +optional
+	RemovedLatestTag string `json:"removedLatestTag,omitempty"`
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +optional
@@ -184,6 +615,16 @@ type ImagePolicyStatus struct {
 	meta.ReconcileRequestStatus `json:",inline"`
 }
 
+// ExtractCollision describes several tags sharing the same extracted value.
+type ExtractCollision struct {
+	// Value is the extracted value shared by the tags.
+	// +required
+	Value string `json:"value"`
+	// Tags are the tags sharing the value, in lexicographical order.
+	// +required
+	Tags []string `json:"tags"`
+}
+
 // GetConditions returns the status conditions of the object.
 func (in *ImagePolicy) GetConditions() []metav1.Condition {
 	return in.Status.Conditions
@@ -221,6 +662,13 @@ func (in *ImagePolicy) GetDigestReflectionPolicy() ReflectionPolicy {
 	return ReflectNever
 }
 
+func (in *ImagePolicy) GetLatestTagRemovalPolicy() LatestTagRemovalPolicy {
+	if in.Spec.LatestTagRemovalPolicy != "" {
+		return in.Spec.LatestTagRemovalPolicy
+	}
+	return LatestTagRemovalFallback
+}
+
 func (in *ImagePolicy) GetInterval() time.Duration {
 	if in.GetDigestReflectionPolicy() == ReflectAlways {
 		if in.Spec.Interval == nil || in.Spec.Interval.Duration == 0 {
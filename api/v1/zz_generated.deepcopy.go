@@ -42,6 +42,91 @@ func (in *AlphabeticalPolicy) DeepCopy() *AlphabeticalPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalVerPolicy) DeepCopyInto(out *CalVerPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalVerPolicy.
+func (in *CalVerPolicy) DeepCopy() *CalVerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CalVerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CandidatesSpec) DeepCopyInto(out *CandidatesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CandidatesSpec.
+func (in *CandidatesSpec) DeepCopy() *CandidatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CandidatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreationTimePolicy) DeepCopyInto(out *CreationTimePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreationTimePolicy.
+func (in *CreationTimePolicy) DeepCopy() *CreationTimePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CreationTimePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtractCollision) DeepCopyInto(out *ExtractCollision) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtractCollision.
+func (in *ExtractCollision) DeepCopy() *ExtractCollision {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtractCollision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FloatingTagPolicy) DeepCopyInto(out *FloatingTagPolicy) {
+	*out = *in
+	if in.ResolveVersion != nil {
+		in, out := &in.ResolveVersion, &out.ResolveVersion
+		*out = new(VersionResolution)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FloatingTagPolicy.
+func (in *FloatingTagPolicy) DeepCopy() *FloatingTagPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FloatingTagPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePolicy) DeepCopyInto(out *ImagePolicy) {
 	*out = *in
@@ -75,7 +160,7 @@ func (in *ImagePolicyChoice) DeepCopyInto(out *ImagePolicyChoice) {
 	if in.SemVer != nil {
 		in, out := &in.SemVer, &out.SemVer
 		*out = new(SemVerPolicy)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Alphabetical != nil {
 		in, out := &in.Alphabetical, &out.Alphabetical
@@ -87,6 +172,26 @@ func (in *ImagePolicyChoice) DeepCopyInto(out *ImagePolicyChoice) {
 		*out = new(NumericalPolicy)
 		**out = **in
 	}
+	if in.CalVer != nil {
+		in, out := &in.CalVer, &out.CalVer
+		*out = new(CalVerPolicy)
+		**out = **in
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = new(CreationTimePolicy)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(VersionPolicy)
+		**out = **in
+	}
+	if in.FloatingTag != nil {
+		in, out := &in.FloatingTag, &out.FloatingTag
+		*out = new(FloatingTagPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyChoice.
@@ -136,16 +241,58 @@ func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
 	*out = *in
 	out.ImageRepositoryRef = in.ImageRepositoryRef
 	in.Policy.DeepCopyInto(&out.Policy)
+	if in.MirrorRepositoryRefs != nil {
+		in, out := &in.MirrorRepositoryRefs, &out.MirrorRepositoryRefs
+		*out = make([]meta.NamespacedObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LockstepRepositoryRefs != nil {
+		in, out := &in.LockstepRepositoryRefs, &out.LockstepRepositoryRefs
+		*out = make([]meta.NamespacedObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.CeilingPolicyRef != nil {
+		in, out := &in.CeilingPolicyRef, &out.CeilingPolicyRef
+		*out = new(meta.NamespacedObjectReference)
+		**out = **in
+	}
+	if in.FloorPolicyRef != nil {
+		in, out := &in.FloorPolicyRef, &out.FloorPolicyRef
+		*out = new(meta.NamespacedObjectReference)
+		**out = **in
+	}
 	if in.FilterTags != nil {
 		in, out := &in.FilterTags, &out.FilterTags
 		*out = new(TagFilter)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Interval != nil {
 		in, out := &in.Interval, &out.Interval
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = new(PlatformsSpec)
+		**out = **in
+	}
+	if in.MinimumAge != nil {
+		in, out := &in.MinimumAge, &out.MinimumAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = new(CandidatesSpec)
+		**out = **in
+	}
+	if in.PromotionWindows != nil {
+		in, out := &in.PromotionWindows, &out.PromotionWindows
+		*out = make([]PromotionWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
@@ -171,6 +318,54 @@ func (in *ImagePolicyStatus) DeepCopyInto(out *ImagePolicyStatus) {
 		*out = new(ImageRef)
 		**out = **in
 	}
+	if in.PendingRef != nil {
+		in, out := &in.PendingRef, &out.PendingRef
+		*out = new(ImageRef)
+		**out = **in
+	}
+	if in.NextPromotionTime != nil {
+		in, out := &in.NextPromotionTime, &out.NextPromotionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UnpinnedRef != nil {
+		in, out := &in.UnpinnedRef, &out.UnpinnedRef
+		*out = new(ImageRef)
+		**out = **in
+	}
+	if in.DigestHistory != nil {
+		in, out := &in.DigestHistory, &out.DigestHistory
+		*out = make([]ObservedImageRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlatformDigests != nil {
+		in, out := &in.PlatformDigests, &out.PlatformDigests
+		*out = make([]PlatformDigest, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoakingRef != nil {
+		in, out := &in.SoakingRef, &out.SoakingRef
+		*out = new(SoakingImageRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]ImageRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tracks != nil {
+		in, out := &in.Tracks, &out.Tracks
+		*out = make(map[string]ImageRef, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtractCollision != nil {
+		in, out := &in.ExtractCollision, &out.ExtractCollision
+		*out = new(ExtractCollision)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -359,6 +554,73 @@ func (in *NumericalPolicy) DeepCopy() *NumericalPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedImageRef) DeepCopyInto(out *ObservedImageRef) {
+	*out = *in
+	out.ImageRef = in.ImageRef
+	in.ObservedAt.DeepCopyInto(&out.ObservedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedImageRef.
+func (in *ObservedImageRef) DeepCopy() *ObservedImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformDigest) DeepCopyInto(out *PlatformDigest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformDigest.
+func (in *PlatformDigest) DeepCopy() *PlatformDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformsSpec) DeepCopyInto(out *PlatformsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformsSpec.
+func (in *PlatformsSpec) DeepCopy() *PlatformsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionWindow) DeepCopyInto(out *PromotionWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionWindow.
+func (in *PromotionWindow) DeepCopy() *PromotionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScanResult) DeepCopyInto(out *ScanResult) {
 	*out = *in
@@ -383,6 +645,11 @@ func (in *ScanResult) DeepCopy() *ScanResult {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SemVerPolicy) DeepCopyInto(out *SemVerPolicy) {
 	*out = *in
+	if in.IncludePrereleases != nil {
+		in, out := &in.IncludePrereleases, &out.IncludePrereleases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SemVerPolicy.
@@ -395,9 +662,41 @@ func (in *SemVerPolicy) DeepCopy() *SemVerPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoakingImageRef) DeepCopyInto(out *SoakingImageRef) {
+	*out = *in
+	out.ImageRef = in.ImageRef
+	in.EligibleAt.DeepCopyInto(&out.EligibleAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoakingImageRef.
+func (in *SoakingImageRef) DeepCopy() *SoakingImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SoakingImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TagFilter) DeepCopyInto(out *TagFilter) {
 	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SortKeys != nil {
+		in, out := &in.SortKeys, &out.SortKeys
+		*out = make([]TagSortKey, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagFilter.
@@ -409,3 +708,48 @@ func (in *TagFilter) DeepCopy() *TagFilter {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagSortKey) DeepCopyInto(out *TagSortKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagSortKey.
+func (in *TagSortKey) DeepCopy() *TagSortKey {
+	if in == nil {
+		return nil
+	}
+	out := new(TagSortKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionPolicy) DeepCopyInto(out *VersionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionPolicy.
+func (in *VersionPolicy) DeepCopy() *VersionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionResolution) DeepCopyInto(out *VersionResolution) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionResolution.
+func (in *VersionResolution) DeepCopy() *VersionResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionResolution)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -20,10 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -80,8 +84,89 @@ func (e errInvalidPolicy) Error() string {
 	return e.err.Error()
 }
 
+// errTagCollision is returned when several tags share the value extracted
+// from the latest tag and the collision strategy is set to fail.
+type errTagCollision struct {
+	err       error
+	collision *imagev1.ExtractCollision
+}
+
+// Error implements the error interface.
+func (e errTagCollision) Error() string {
+	return e.err.Error()
+}
+
 var errNoTagsInDatabase = errors.New("no tags in database")
 
+var errBoundPolicyNotReady = errors.New("bound policy has no latest image")
+
+// policyResult is the outcome of applying the policy to the stored tags.
+type policyResult struct {
+	// latest is the elected tag. It is empty if no tag has reached the
+	// minimum age.
+	latest string
+	// soaking is the tag which would be elected if it had reached the
+	// minimum age.
+	soaking string
+	// eligibleAt is the time at which the soaking tag reaches the minimum
+	// age.
+	eligibleAt time.Time
+	// candidates are the top ranked tags, starting with the latest one.
+	candidates []string
+	// tracks are the latest tags of each release track, keyed by track.
+	tracks map[string]string
+	// collision holds the tags sharing the value extracted from the latest
+	// one, if any.
+	collision *imagev1.ExtractCollision
+	// rejected is the tag elected by the policy which was rejected as it
+	// ranks lower than the current latest tag.
+	rejected string
+	// removed is the current latest tag if it is not listed in the image
+	// repository anymore.
+	removed string
+	// unpinned is the tag elected by the policy when the latest tag is
+	// pinned.
+	unpinned string
+	// newer is true if the unpinned tag ranks higher than the pinned one.
+	newer bool
+	// sources maps the tags only listed in mirror repositories to the first
+	// one listing them.
+	sources map[string]*imagev1.ImageRepository
+	// versions are the versioned tags the latest floating tag may be
+	// resolved to, starting with the highest version.
+	versions []string
+}
+
+// tagSource returns the repository supplying the given tag, which is the
+// given one unless the tag is only listed in a mirror repository.
+func tagSource(repo *imagev1.ImageRepository, sources map[string]*imagev1.ImageRepository, tag string) *imagev1.ImageRepository {
+	if source, ok := sources[tag]; ok {
+		return source
+	}
+	return repo
+}
+
+// pin returns the result with the given tag as the latest one, keeping the
+// tag elected by the policy as the unpinned one and whether the policer ranks
+// it higher than the pinned tag. The tags held back from the latest one are
+// dropped, as they do not apply to the pinned tag.
+func (res policyResult) pin(tag string, policer policy.Policer) policyResult {
+	unpinned := res.latest
+	if res.rejected != "" {
+		unpinned = res.rejected
+	}
+	c, ok := compareTags(policer, unpinned, tag)
+	return policyResult{
+		latest:     tag,
+		unpinned:   unpinned,
+		newer:      unpinned != "" && ok && c > 0,
+		candidates: res.candidates,
+		tracks:     res.tracks,
+		collision:  res.collision,
+		sources:    res.sources,
+	}
+}
+
 // imagePolicyOwnedConditions is a list of conditions owned by the
 // ImagePolicyReconciler.
 var imagePolicyOwnedConditions = []string{
@@ -95,6 +180,9 @@ var imagePolicyOwnedConditions = []string{
 // from.
 const imageRepoKey = ".spec.imageRepository"
 
+// boundPolicyKey is the key for the index of policy->bound policies.
+const boundPolicyKey = ".spec.boundPolicyRefs"
+
 // +kubebuilder:rbac:groups=image.toolkit.fluxcd.io,resources=imagepolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=image.toolkit.fluxcd.io,resources=imagepolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=image.toolkit.fluxcd.io,resources=imagerepositories,verbs=get;list;watch
@@ -110,6 +198,7 @@ type ImagePolicyReconciler struct {
 
 	ControllerName            string
 	Database                  storage.DatabaseReader
+	MetadataCache             storage.MetadataCache
 	ACLOptions                acl.Options
 	AuthOptionsGetter         *registry.AuthOptionsGetter
 	TokenCache                *cache.TokenCache
@@ -125,33 +214,62 @@ type ImagePolicyReconcilerOptions struct {
 func (r *ImagePolicyReconciler) SetupWithManager(mgr ctrl.Manager, opts ImagePolicyReconcilerOptions) error {
 	r.patchOptions = getPatchOptions(imagePolicyOwnedConditions, r.ControllerName)
 
-	// index the policies by which image repo they point at, so that
-	// it's easy to list those out when an image repo changes.
+	// index the policies by which image repos they point at, including the
+	// mirror and lockstep ones, so that it's easy to list those out when an image repo
+	// changes.
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &imagev1.ImagePolicy{}, imageRepoKey, func(obj client.Object) []string {
 		pol := obj.(*imagev1.ImagePolicy)
 
-		namespace := pol.Spec.ImageRepositoryRef.Namespace
-		if namespace == "" {
-			namespace = obj.GetNamespace()
+		refs := append([]meta.NamespacedObjectReference{pol.Spec.ImageRepositoryRef}, pol.Spec.MirrorRepositoryRefs...)
+		refs = append(refs, pol.Spec.LockstepRepositoryRefs...)
+		keys := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = obj.GetNamespace()
+			}
+			namespacedName := types.NamespacedName{
+				Name:      ref.Name,
+				Namespace: namespace,
+			}
+			keys = append(keys, namespacedName.String())
 		}
-		namespacedName := types.NamespacedName{
-			Name:      pol.Spec.ImageRepositoryRef.Name,
-			Namespace: namespace,
+		return keys
+	}); err != nil {
+		return err
+	}
+
+	// index the policies by which policies bound them, so that it's easy
+	// to list those out when the latest image of a policy changes.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &imagev1.ImagePolicy{}, boundPolicyKey, func(obj client.Object) []string {
+		pol := obj.(*imagev1.ImagePolicy)
+
+		var keys []string
+		for _, ref := range []*meta.NamespacedObjectReference{pol.Spec.CeilingPolicyRef, pol.Spec.FloorPolicyRef} {
+			if ref != nil {
+				keys = append(keys, boundPolicyName(pol, *ref).String())
+			}
 		}
-		return []string{namespacedName.String()}
+		return keys
 	}); err != nil {
 		return err
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&imagev1.ImagePolicy{}, builder.WithPredicates(
-			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{}),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicates.ReconcileRequestedPredicate{},
+				approvedRefPredicate{}),
 		)).
 		Watches(
 			&imagev1.ImageRepository{},
 			handler.EnqueueRequestsFromMapFunc(r.imagePoliciesForRepository),
 			builder.WithPredicates(imageRepositoryPredicate{}),
 		).
+		Watches(
+			&imagev1.ImagePolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.imagePoliciesForBoundPolicy),
+			builder.WithPredicates(latestRefPredicate{}),
+		).
 		WithOptions(controller.Options{
 			RateLimiter: opts.RateLimiter,
 		}).
@@ -184,6 +302,40 @@ func (imageRepositoryPredicate) Update(e event.UpdateEvent) bool {
 	return false
 }
 
+// latestRefPredicate is used for watching changes to the latest image of
+// ImagePolicy objects that bound other ImagePolicy objects.
+type latestRefPredicate struct {
+	predicate.Funcs
+}
+
+func (latestRefPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldRef := e.ObjectOld.(*imagev1.ImagePolicy).Status.LatestRef
+	newRef := e.ObjectNew.(*imagev1.ImagePolicy).Status.LatestRef
+	if oldRef == nil || newRef == nil {
+		return oldRef != newRef
+	}
+	return *oldRef != *newRef
+}
+
+// approvedRefPredicate is used for reconciling ImagePolicy objects when
+// the image pending approval is approved.
+type approvedRefPredicate struct {
+	predicate.Funcs
+}
+
+func (approvedRefPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return e.ObjectOld.GetAnnotations()[imagev1.ApprovedRefAnnotation] !=
+		e.ObjectNew.GetAnnotations()[imagev1.ApprovedRefAnnotation]
+}
+
 func (r *ImagePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, retErr error) {
 	start := time.Now()
 	log := ctrl.LoggerFrom(ctx)
@@ -248,17 +400,37 @@ func (r *ImagePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 // composeImagePolicyReadyMessage composes a Ready message for an ImagePolicy
 // based on the results of applying the policy.
 func composeImagePolicyReadyMessage(obj *imagev1.ImagePolicy) string {
-	latestRef := obj.Status.LatestRef
-	readyMsg := fmt.Sprintf("Latest image tag for %s resolved to %s", latestRef.Name, latestRef.Tag)
-	if latestRef.Digest != "" {
-		readyMsg += fmt.Sprintf(" with digest %s", latestRef.Digest)
+	var readyMsg string
+	if latestRef := obj.Status.LatestRef; latestRef != nil {
+		readyMsg = fmt.Sprintf("Latest image tag for %s resolved to %s", latestRef.Name, latestRef.Tag)
+		if latestRef.Digest != "" {
+			readyMsg += fmt.Sprintf(" with digest %s", latestRef.Digest)
+		}
+		if prev := obj.Status.ObservedPreviousRef; prev != nil && *latestRef != *prev {
+			readyMsg += fmt.Sprintf(" (previously %s:%s", prev.Name, prev.Tag)
+			if prev.Digest != "" {
+				readyMsg += fmt.Sprintf("@%s", prev.Digest)
+			}
+			readyMsg += ")"
+		}
+	} else if pending := obj.Status.PendingRef; pending != nil {
+		// No image was approved yet.
+		readyMsg = fmt.Sprintf("No image tag for %s approved yet", pending.Name)
+	}
+	if obj.Spec.Pin != "" {
+		readyMsg += ", pinned"
 	}
-	if prev := obj.Status.ObservedPreviousRef; prev != nil && *latestRef != *prev {
-		readyMsg += fmt.Sprintf(" (previously %s:%s", prev.Name, prev.Tag)
-		if prev.Digest != "" {
-			readyMsg += fmt.Sprintf("@%s", prev.Digest)
+	if pending := obj.Status.PendingRef; pending != nil {
+		if next := obj.Status.NextPromotionTime; next != nil {
+			readyMsg += fmt.Sprintf(", %s is awaiting the promotion window opening at %s",
+				pending.String(), next.UTC().Format(time.RFC3339))
+		} else {
+			readyMsg += fmt.Sprintf(", %s is awaiting approval", pending.String())
 		}
-		readyMsg += ")"
+	}
+	if soaking := obj.Status.SoakingRef; soaking != nil {
+		readyMsg += fmt.Sprintf(", %s is waiting for the minimum age until %s",
+			soaking.Tag, soaking.EligibleAt.UTC().Format(time.RFC3339))
 	}
 	return readyMsg
 }
@@ -302,6 +474,20 @@ func (r *ImagePolicyReconciler) reconcile(ctx context.Context, sp *patch.SerialP
 		return
 	}
 
+	if obj.Spec.Policy.FloatingTag != nil && obj.GetDigestReflectionPolicy() != imagev1.ReflectAlways {
+		const msg = "spec.digestReflectionPolicy must be set to 'Always' when spec.policy.floatingTag is set"
+		conditions.MarkStalled(obj, "InvalidPolicy", msg)
+		result, retErr = ctrl.Result{}, nil
+		return
+	}
+
+	if obj.Spec.Platforms != nil && obj.GetDigestReflectionPolicy() == imagev1.ReflectNever {
+		const msg = "spec.digestReflectionPolicy must not be set to 'Never' when spec.platforms is set"
+		conditions.MarkStalled(obj, "InvalidPolicy", msg)
+		result, retErr = ctrl.Result{}, nil
+		return
+	}
+
 	// Set reconciling condition.
 	pkgreconcile.ProgressiveStatus(false, obj, meta.ProgressingReason, "reconciliation in progress")
 
@@ -356,7 +542,7 @@ func (r *ImagePolicyReconciler) reconcile(ctx context.Context, sp *patch.SerialP
 	// Construct a policer from the spec.policy.
 	// Read the tags from database and use the policy to obtain a result for the
 	// latest tag.
-	latest, err := r.applyPolicy(ctx, obj, repo)
+	res, err := r.applyPolicy(ctx, obj, repo)
 	if err != nil {
 		// Stall if it's an invalid policy.
 		if _, ok := err.(errInvalidPolicy); ok {
@@ -365,22 +551,112 @@ func (r *ImagePolicyReconciler) reconcile(ctx context.Context, sp *patch.SerialP
 			return
 		}
 
-		// If there's no tag in the database, mark not ready and
-		// requeue according to --requeue-dependency flag.
-		if errors.Is(err, errNoTagsInDatabase) {
+		// Stall if the latest tag is ambiguous, until the tags or the
+		// spec change.
+		if e, ok := err.(errTagCollision); ok {
+			obj.Status.ExtractCollision = e.collision
+			conditions.MarkStalled(obj, imagev1.TagCollisionReason, "%s", err)
+			result, retErr = ctrl.Result{}, nil
+			return
+		}
+
+		// If there's no tag in the database, a lockstep repository does
+		// not exist, or a bound policy has no latest image, mark not ready
+		// and requeue according to --requeue-dependency flag.
+		if errors.Is(err, errNoTagsInDatabase) || errors.Is(err, errBoundPolicyNotReady) || apierrors.IsNotFound(err) {
 			depsErr := fmt.Errorf("retrying in %s error: %w", r.DependencyRequeueInterval.Round(time.Second), err)
 			conditions.MarkFalse(obj, meta.ReadyCondition, imagev1.DependencyNotReadyReason, "%s", depsErr)
 			result, retErr = ctrl.Result{RequeueAfter: r.DependencyRequeueInterval}, nil
 			return
 		}
 
-		conditions.MarkFalse(obj, meta.ReadyCondition, metav1.StatusFailure, "%s", err)
+		reason := metav1.StatusFailure
+		if _, ok := err.(errAccessDenied); ok {
+			reason = aclapi.AccessDeniedReason
+		}
+		conditions.MarkFalse(obj, meta.ReadyCondition, reason, "%s", err)
 		result, retErr = ctrl.Result{}, err
 		return
 	}
 
-	// Update status fields with the latest tag and digest.
-	if err := r.updateImageRefs(ctx, repo, obj, latest); err != nil {
+	// Stall if the latest tag was removed from the image repository and the
+	// policy says so, until it is listed again or the spec changes.
+	if res.removed != "" && obj.GetLatestTagRemovalPolicy() == imagev1.LatestTagRemovalFail {
+		conditions.MarkStalled(obj, imagev1.LatestTagRemovedReason,
+			"latest tag %s is not listed in the image repository anymore", res.removed)
+		result, retErr = ctrl.Result{}, nil
+		return
+	}
+
+	// Report the tag waiting for the minimum age, and reconcile again once it
+	// reaches it.
+	obj.Status.SoakingRef = nil
+	var soakDuration time.Duration
+	if res.soaking != "" {
+		obj.Status.SoakingRef = &imagev1.SoakingImageRef{
+			ImageRef:   imagev1.ImageRef{Name: tagSource(repo, res.sources, res.soaking).Spec.Image, Tag: res.soaking},
+			EligibleAt: metav1.NewTime(res.eligibleAt),
+		}
+		soakDuration = time.Until(res.eligibleAt)
+	}
+	if res.latest == "" {
+		msg := fmt.Sprintf("no tag has reached the minimum age of %s, retrying in %s",
+			obj.Spec.MinimumAge.Duration, soakDuration.Round(time.Second))
+		conditions.MarkFalse(obj, meta.ReadyCondition, imagev1.MinimumAgeNotReachedReason, "%s", msg)
+		result, retErr = ctrl.Result{RequeueAfter: soakDuration}, nil
+		return
+	}
+	if soakDuration > 0 && (nextReconcileTime == 0 || soakDuration < nextReconcileTime) {
+		nextReconcileTime = soakDuration
+	}
+
+	// Update status fields with the latest tag and digest. The current ones
+	// are left untouched when a downgrade is prevented or the removed latest
+	// tag is kept, as the tag may not exist anymore.
+	if res.rejected == "" && (res.removed == "" || res.latest != res.removed) {
+		nextWindow, err := nextPromotionWindow(obj.Spec.PromotionWindows, time.Now())
+		if err != nil {
+			conditions.MarkStalled(obj, "InvalidPolicy", "%s", err)
+			result, retErr = ctrl.Result{}, nil
+			return
+		}
+		if err := r.updateImageRefs(ctx, tagSource(repo, res.sources, res.latest), obj, res.latest, res.versions, nextWindow); err != nil {
+			result, retErr = ctrl.Result{}, err
+			return
+		}
+	} else {
+		obj.Status.PendingRef = nil
+		obj.Status.NextPromotionTime = nil
+	}
+
+	// Reconcile again once the next promotion window opens, if an image is
+	// held until then.
+	if next := obj.Status.NextPromotionTime; next != nil {
+		if d := time.Until(next.Time); nextReconcileTime == 0 || d < nextReconcileTime {
+			nextReconcileTime = max(d, time.Second)
+		}
+	}
+
+	// Update status field with the image elected by the policy, if pinned.
+	obj.Status.UnpinnedRef = nil
+	if res.unpinned != "" {
+		obj.Status.UnpinnedRef = &imagev1.ImageRef{Name: tagSource(repo, res.sources, res.unpinned).Spec.Image, Tag: res.unpinned}
+	}
+
+	// Update status field with the tags sharing the latest extracted value.
+	obj.Status.ExtractCollision = res.collision
+
+	// Update status field with the latest tag of each release track.
+	obj.Status.Tracks = nil
+	for track, tag := range res.tracks {
+		if obj.Status.Tracks == nil {
+			obj.Status.Tracks = make(map[string]imagev1.ImageRef, len(res.tracks))
+		}
+		obj.Status.Tracks[track] = imagev1.ImageRef{Name: tagSource(repo, res.sources, tag).Spec.Image, Tag: tag}
+	}
+
+	// Update status field with the top ranked candidates.
+	if err := r.updateCandidates(ctx, repo, obj, res.candidates, res.sources); err != nil {
 		result, retErr = ctrl.Result{}, err
 		return
 	}
@@ -388,8 +664,37 @@ func (r *ImagePolicyReconciler) reconcile(ctx context.Context, sp *patch.SerialP
 	// Compute ready message.
 	readyMsg = composeImagePolicyReadyMessage(obj)
 
-	// Let result finalizer compute the Ready condition.
+	// Let result finalizer compute the Ready condition, unless the latest tag
+	// is pinned or was removed, a downgrade was prevented or an image is
+	// pending. The removal is only notified when first detected, i.e. when
+	// the status does not record the removed tag yet.
 	conditions.Delete(obj, meta.ReadyCondition)
+	if res.removed != "" {
+		msg := fmt.Sprintf("latest tag %s is not listed in the image repository anymore", res.removed)
+		if obj.Status.RemovedLatestTag != res.removed {
+			eventLogf(ctx, r.EventRecorder, obj, corev1.EventTypeWarning, imagev1.LatestTagRemovedReason, "%s", msg)
+		}
+		readyMsg += ", " + msg
+	}
+	obj.Status.RemovedLatestTag = res.removed
+	if res.rejected != "" {
+		readyMsg += fmt.Sprintf(", downgrade to %s prevented", res.rejected)
+	}
+	if res.newer {
+		readyMsg += fmt.Sprintf(", newer available: %s", res.unpinned)
+	}
+	switch {
+	case obj.Spec.Pin != "":
+		conditions.MarkTrue(obj, meta.ReadyCondition, imagev1.PinnedReason, "%s", readyMsg)
+	case obj.Status.NextPromotionTime != nil:
+		conditions.MarkTrue(obj, meta.ReadyCondition, imagev1.OutsidePromotionWindowReason, "%s", readyMsg)
+	case obj.Status.PendingRef != nil:
+		conditions.MarkTrue(obj, meta.ReadyCondition, imagev1.AwaitingApprovalReason, "%s", readyMsg)
+	case res.removed != "":
+		conditions.MarkTrue(obj, meta.ReadyCondition, imagev1.LatestTagRemovedReason, "%s", readyMsg)
+	case res.rejected != "":
+		conditions.MarkTrue(obj, meta.ReadyCondition, imagev1.DowngradePreventedReason, "%s", readyMsg)
+	}
 
 	// Set the next reconcile time in the result based on the interval.
 	result, retErr = ctrl.Result{RequeueAfter: nextReconcileTime}, nil
@@ -398,9 +703,11 @@ func (r *ImagePolicyReconciler) reconcile(ctx context.Context, sp *patch.SerialP
 
 // updateImageRefs updates the status fields of the ImagePolicy with the
 // latest image and digest. It takes the digest reflection policy into
-// account and fetches the digest if needed.
-func (r *ImagePolicyReconciler) updateImageRefs(ctx context.Context,
-	repo *imagev1.ImageRepository, obj *imagev1.ImagePolicy, latest string) error {
+// account and fetches the digest if needed. The image is held in the pending
+// ref while awaiting approval, or until nextWindow if it is not zero. The tag
+// is resolved to the first of the given versions sharing its digest, if any.
+func (r *ImagePolicyReconciler) updateImageRefs(ctx context.Context, repo *imagev1.ImageRepository,
+	obj *imagev1.ImagePolicy, latest string, versions []string, nextWindow time.Time) error {
 
 	latestRef := &imagev1.ImageRef{
 		Name: repo.Spec.Image,
@@ -415,30 +722,274 @@ func (r *ImagePolicyReconciler) updateImageRefs(ctx context.Context,
 		shouldFetch = obj.Status.LatestRef == nil ||
 			obj.Status.LatestRef.Name != latestRef.Name ||
 			obj.Status.LatestRef.Tag != latestRef.Tag ||
-			obj.Status.LatestRef.Digest == ""
+			obj.Status.LatestRef.Digest == "" ||
+			!platformsReflected(obj)
 
 		if !shouldFetch {
 			latestRef.Digest = obj.Status.LatestRef.Digest
 		}
 
+		// Reuse the digest of the image pending approval, unless the
+		// platform digests are needed.
+		if pending := obj.Status.PendingRef; shouldFetch && obj.Spec.Platforms == nil && pending != nil &&
+			pending.Name == latestRef.Name && pending.Tag == latestRef.Tag && pending.Digest != "" {
+			shouldFetch = false
+			latestRef.Digest = pending.Digest
+		}
+
 	case imagev1.ReflectAlways:
 		shouldFetch = true
 	}
 
-	// Fetch the digest if needed.
+	// Use the pinned digest, if any.
+	if _, digest, _ := strings.Cut(obj.Spec.Pin, "@"); digest != "" {
+		shouldFetch = false
+		latestRef.Digest = digest
+	}
+
+	// Fetch the digest if needed, along with the platform digests if
+	// configured.
+	var platforms []imagev1.PlatformDigest
+	var manifestDigest string
 	if shouldFetch {
-		digest, err := r.fetchDigest(ctx, repo, obj, latest)
+		var digest string
+		var err error
+		if obj.Spec.Platforms != nil {
+			digest, platforms, err = r.fetchPlatformDigests(ctx, repo, obj, latest)
+			manifestDigest = digest
+		} else {
+			digest, err = r.fetchDigest(ctx, repo, obj, latest)
+		}
 		if err != nil {
 			return fmt.Errorf("failed fetching digest of %s: %w", latestRef.String(), err)
 		}
 		latestRef.Digest = digest
 	}
 
+	// Report the versioned tag sharing the digest of the floating tag.
+	if latestRef.Digest != "" && len(versions) > 0 {
+		version, err := r.resolveVersion(ctx, repo, obj, latestRef.Digest, versions)
+		if err != nil {
+			return fmt.Errorf("failed resolving %s to a versioned tag: %w", latestRef.String(), err)
+		}
+		if version != "" {
+			latestRef.Tag = version
+		}
+	}
+
+	// Use the digest of the selected platform manifest, if the image is an
+	// index.
+	if p := obj.Spec.Platforms; p != nil && p.Select != "" && platforms != nil {
+		i := selectPlatform(platforms, p.Select)
+		if i < 0 {
+			return fmt.Errorf("image %s has no manifest for platform %s", latestRef.String(), p.Select)
+		}
+		latestRef.Digest = platforms[i].Digest
+	}
+	if obj.Spec.Platforms == nil {
+		obj.Status.PlatformDigests = nil
+		obj.Status.ManifestDigest = ""
+	}
+
+	// Record the digests of the followed tag.
+	if obj.Spec.Policy.FloatingTag == nil {
+		obj.Status.DigestHistory = nil
+	} else if latestRef.Digest != "" {
+		recordDigest(obj, *latestRef, time.Now())
+	}
+
+	// Hold the resulting ref until it is approved, if required, and until the
+	// next promotion window opens, if none is open, unless it is pinned.
+	obj.Status.PendingRef = nil
+	obj.Status.NextPromotionTime = nil
+	if obj.Status.LatestRef != nil && *latestRef == *obj.Status.LatestRef {
+		if shouldFetch && obj.Spec.Platforms != nil {
+			obj.Status.PlatformDigests = platforms
+			obj.Status.ManifestDigest = manifestDigest
+		}
+		return nil
+	}
+	if obj.Spec.Pin == "" && obj.Spec.RequireApproval && !isApproved(obj, latestRef) {
+		obj.Status.PendingRef = latestRef
+		return nil
+	}
+	if obj.Spec.Pin == "" && !nextWindow.IsZero() {
+		obj.Status.PendingRef = latestRef
+		obj.Status.NextPromotionTime = &metav1.Time{Time: nextWindow}
+		return nil
+	}
+
 	// Update the status fields only if the resulting ref is different.
-	if obj.Status.LatestRef == nil || *latestRef != *obj.Status.LatestRef {
-		obj.Status.ObservedPreviousRef = obj.Status.LatestRef
-		obj.Status.LatestRef = latestRef
+	obj.Status.ObservedPreviousRef = obj.Status.LatestRef
+	obj.Status.LatestRef = latestRef
+	obj.Status.PlatformDigests = platforms
+	obj.Status.ManifestDigest = manifestDigest
+
+	return nil
+}
+
+// platformsReflected reports whether the platform digests of the current
+// latest image are reflected according to .spec.platforms, i.e. its manifest
+// was read and its digest is the one of the selected platform, if any and the
+// image is an index, or the one of the manifest otherwise.
+func platformsReflected(obj *imagev1.ImagePolicy) bool {
+	p := obj.Spec.Platforms
+	if p == nil {
+		return true
+	}
+	if obj.Status.ManifestDigest == "" {
+		return false
+	}
+	want := obj.Status.ManifestDigest
+	if digests := obj.Status.PlatformDigests; p.Select != "" && digests != nil {
+		i := selectPlatform(digests, p.Select)
+		if i < 0 {
+			return false
+		}
+		want = digests[i].Digest
+	}
+	return obj.Status.LatestRef.Digest == want
+}
+
+// selectPlatform returns the index of the given platform digests matching the
+// given platform, or -1 if none does.
+func selectPlatform(digests []imagev1.PlatformDigest, platform string) int {
+	platforms := make([]string, 0, len(digests))
+	for _, pd := range digests {
+		platforms = append(platforms, pd.Platform)
+	}
+	return registry.SelectPlatform(platform, platforms)
+}
+
+// resolveVersion returns the first of the given versioned tags whose digest
+// is the given one, or an empty string if there is none. The digests are
+// fetched in order, unless the current latest tag is one of the versions and
+// already has the given digest.
+func (r *ImagePolicyReconciler) resolveVersion(ctx context.Context, repo *imagev1.ImageRepository,
+	obj *imagev1.ImagePolicy, digest string, versions []string) (string, error) {
+
+	if current := obj.Status.LatestRef; current != nil && current.Name == repo.Spec.Image &&
+		current.Digest == digest && slices.Contains(versions, current.Tag) {
+		return current.Tag, nil
+	}
+	for _, version := range versions {
+		d, err := r.fetchDigest(ctx, repo, obj, version)
+		if err != nil {
+			return "", err
+		}
+		if d == digest {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
+// maxDigestHistory is the maximum number of digests recorded for the tag
+// followed by a floating tag policy.
+const maxDigestHistory = 10
+
+// recordDigest records the given image in the digest history of the given
+// ImagePolicy, unless it is already the newest entry.
+func recordDigest(obj *imagev1.ImagePolicy, ref imagev1.ImageRef, now time.Time) {
+	history := obj.Status.DigestHistory
+	if len(history) > 0 && history[0].ImageRef == ref {
+		return
+	}
+	history = append([]imagev1.ObservedImageRef{{ImageRef: ref, ObservedAt: metav1.NewTime(now)}}, history...)
+	if len(history) > maxDigestHistory {
+		history = history[:maxDigestHistory]
+	}
+	obj.Status.DigestHistory = history
+}
+
+// isApproved reports whether the approved-ref annotation of the given
+// ImagePolicy references the given image by digest or tag@digest. When the
+// digest of the image is not known, the image can only be approved by tag,
+// as approving a mutable tag alone would approve any image pushed to it.
+func isApproved(obj *imagev1.ImagePolicy, ref *imagev1.ImageRef) bool {
+	approved := obj.GetAnnotations()[imagev1.ApprovedRefAnnotation]
+	if approved == "" {
+		return false
+	}
+	if tag, digest, ok := strings.Cut(approved, "@"); ok {
+		return tag == ref.Tag && digest != "" && digest == ref.Digest
+	}
+	if ref.Digest != "" {
+		return approved == ref.Digest
+	}
+	return approved == ref.Tag
+}
+
+// updateCandidates updates the status field of the ImagePolicy with the top
+// ranked candidates, fetching their digests if configured. The digests
+// already reflected for the same tags are reused, unless the digest
+// reflection policy is Always. The candidates only listed in mirror
+// repositories are looked up in the given sources.
+func (r *ImagePolicyReconciler) updateCandidates(ctx context.Context, repo *imagev1.ImageRepository,
+	obj *imagev1.ImagePolicy, candidates []string, sources map[string]*imagev1.ImageRepository) error {
+
+	if obj.Spec.Candidates == nil {
+		obj.Status.Candidates = nil
+		return nil
+	}
+
+	if !obj.Spec.Candidates.ReflectDigests {
+		refs := make([]imagev1.ImageRef, 0, len(candidates))
+		for _, tag := range candidates {
+			refs = append(refs, imagev1.ImageRef{Name: tagSource(repo, sources, tag).Spec.Image, Tag: tag})
+		}
+		obj.Status.Candidates = refs
+		return nil
+	}
+
+	// Reuse the digests of the images already reflected, unless the digests
+	// are to be fetched on every reconciliation.
+	known := make(map[imagev1.ImageRef]string)
+	if obj.GetDigestReflectionPolicy() != imagev1.ReflectAlways {
+		for _, c := range obj.Status.Candidates {
+			if c.Digest != "" {
+				known[imagev1.ImageRef{Name: c.Name, Tag: c.Tag}] = c.Digest
+			}
+		}
+	}
+	if latest := obj.Status.LatestRef; latest != nil && latest.Digest != "" {
+		known[imagev1.ImageRef{Name: latest.Name, Tag: latest.Tag}] = latest.Digest
+	}
+
+	// The auth options of each source are built once and shared by the
+	// requests, which are all bounded by the repository timeout.
+	ctx, cancel := context.WithTimeout(ctx, repo.GetTimeout())
+	defer cancel()
+	sourceOpts := make(map[*imagev1.ImageRepository][]remote.Option)
+
+	refs := make([]imagev1.ImageRef, 0, len(candidates))
+	for _, tag := range candidates {
+		source := tagSource(repo, sources, tag)
+		ref := imagev1.ImageRef{
+			Name: source.Spec.Image,
+			Tag:  tag,
+		}
+		if digest, ok := known[ref]; ok {
+			ref.Digest = digest
+			refs = append(refs, ref)
+			continue
+		}
+		opts, ok := sourceOpts[source]
+		if !ok {
+			var err error
+			if opts, err = r.remoteOptions(ctx, source, obj); err != nil {
+				return err
+			}
+			sourceOpts[source] = opts
+		}
+		digest, err := headDigest(source, tag, opts)
+		if err != nil {
+			return fmt.Errorf("failed fetching digest of candidate %s: %w", ref.String(), err)
+		}
+		ref.Digest = digest
+		refs = append(refs, ref)
 	}
+	obj.Status.Candidates = refs
 
 	return nil
 }
@@ -447,11 +998,50 @@ func (r *ImagePolicyReconciler) updateImageRefs(ctx context.Context,
 func (r *ImagePolicyReconciler) fetchDigest(ctx context.Context,
 	repo *imagev1.ImageRepository, obj *imagev1.ImagePolicy, latest string) (string, error) {
 
-	ref := repo.Spec.Image + ":" + latest
+	ctx, cancel := context.WithTimeout(ctx, repo.GetTimeout())
+	defer cancel()
+	opts, err := r.remoteOptions(ctx, repo, obj)
+	if err != nil {
+		return "", err
+	}
+
+	return headDigest(repo, latest, opts)
+}
+
+// headDigest fetches the digest of the given tag of the image of the given
+// repository with a HEAD request, using the given options.
+func headDigest(repo *imagev1.ImageRepository, tag string, opts []remote.Option) (string, error) {
+	tagRef, err := tagReference(repo, tag)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := remote.Head(tagRef, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed fetching descriptor for %q: %w", tagRef.String(), err)
+	}
+
+	return desc.Digest.String(), nil
+}
+
+// tagReference parses the reference to the given tag of the image of the
+// given repository.
+func tagReference(repo *imagev1.ImageRepository, tag string) (name.Reference, error) {
+	ref := repo.Spec.Image + ":" + tag
 	tagRef, err := name.ParseReference(ref)
 	if err != nil {
-		return "", fmt.Errorf("failed parsing reference %q: %w", ref, err)
+		return nil, fmt.Errorf("failed parsing reference %q: %w", ref, err)
 	}
+	return tagRef, nil
+}
+
+// remoteOptions returns the options for the requests made to the registry of
+// the given repository on behalf of the given ImagePolicy. The given context
+// must be bounded by the repository timeout, which must span both building
+// the auth options and the registry requests, as the authenticator fetches
+// registry credentials lazily during the requests.
+func (r *ImagePolicyReconciler) remoteOptions(ctx context.Context,
+	repo *imagev1.ImageRepository, obj *imagev1.ImagePolicy) ([]remote.Option, error) {
 
 	involvedObject := &cache.InvolvedObject{
 		Kind:      imagev1.ImagePolicyKind,
@@ -459,36 +1049,65 @@ func (r *ImagePolicyReconciler) fetchDigest(ctx context.Context,
 		Namespace: obj.GetNamespace(),
 		Operation: cache.OperationReconcile,
 	}
+	opts, err := r.AuthOptionsGetter.GetOptions(ctx, repo, involvedObject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication options: %w", err)
+	}
+	return append(opts, remote.WithContext(ctx)), nil
+}
+
+// fetchPlatformDigests fetches the digest of the given image repository and
+// latest tag, along with the digest of each platform manifest if it is an
+// image index.
+func (r *ImagePolicyReconciler) fetchPlatformDigests(ctx context.Context,
+	repo *imagev1.ImageRepository, obj *imagev1.ImagePolicy, latest string) (string, []imagev1.PlatformDigest, error) {
+
+	tagRef, err := tagReference(repo, latest)
+	if err != nil {
+		return "", nil, err
+	}
 
-	// The timeout must span both building the auth options and the registry
-	// request, as the authenticator fetches registry credentials lazily during
-	// the request.
 	ctx, cancel := context.WithTimeout(ctx, repo.GetTimeout())
 	defer cancel()
-
-	opts, err := r.AuthOptionsGetter.GetOptions(ctx, repo, involvedObject)
+	opts, err := r.remoteOptions(ctx, repo, obj)
 	if err != nil {
-		return "", fmt.Errorf("failed to configure authentication options: %w", err)
+		return "", nil, err
 	}
 
-	desc, err := remote.Head(tagRef, append(opts, remote.WithContext(ctx))...)
+	desc, err := remote.Get(tagRef, opts...)
 	if err != nil {
-		return "", fmt.Errorf("failed fetching descriptor for %q: %w", tagRef.String(), err)
+		return "", nil, fmt.Errorf("failed fetching manifest for %q: %w", tagRef.String(), err)
+	}
+	manifests, err := registry.PlatformManifests(desc)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed reading platform manifests of %q: %w", tagRef.String(), err)
 	}
 
-	return desc.Digest.String(), nil
+	var platforms []imagev1.PlatformDigest
+	for _, m := range manifests {
+		platforms = append(platforms, imagev1.PlatformDigest{Platform: m.Platform, Digest: m.Digest})
+	}
+	return desc.Digest.String(), platforms, nil
 }
 
 // getImageRepository tries to fetch an ImageRepository referenced by the given
 // ImagePolicy if it's accessible.
 func (r *ImagePolicyReconciler) getImageRepository(ctx context.Context, obj *imagev1.ImagePolicy) (*imagev1.ImageRepository, error) {
+	return r.getReferencedImageRepository(ctx, obj, obj.Spec.ImageRepositoryRef)
+}
+
+// getReferencedImageRepository tries to fetch the given ImageRepository
+// reference of the given ImagePolicy if it's accessible.
+func (r *ImagePolicyReconciler) getReferencedImageRepository(ctx context.Context,
+	obj *imagev1.ImagePolicy, ref meta.NamespacedObjectReference) (*imagev1.ImageRepository, error) {
+
 	repo := &imagev1.ImageRepository{}
 	repoNamespacedName := types.NamespacedName{
 		Namespace: obj.Namespace,
-		Name:      obj.Spec.ImageRepositoryRef.Name,
+		Name:      ref.Name,
 	}
-	if obj.Spec.ImageRepositoryRef.Namespace != "" {
-		repoNamespacedName.Namespace = obj.Spec.ImageRepositoryRef.Namespace
+	if ref.Namespace != "" {
+		repoNamespacedName.Namespace = ref.Namespace
 	}
 
 	// If NoCrossNamespaceRefs is true and ImageRepository and ImagePolicy are
@@ -517,34 +1136,676 @@ func (r *ImagePolicyReconciler) getImageRepository(ctx context.Context, obj *ima
 
 // applyPolicy reads the tags of the given repository from the internal database
 // and applies the tag filters and constraints to return the latest image.
-func (r *ImagePolicyReconciler) applyPolicy(ctx context.Context, obj *imagev1.ImagePolicy, repo *imagev1.ImageRepository) (string, error) {
+func (r *ImagePolicyReconciler) applyPolicy(ctx context.Context, obj *imagev1.ImagePolicy, repo *imagev1.ImageRepository) (policyResult, error) {
 	policer, err := policy.PolicerFromSpec(obj.Spec.Policy)
 	if err != nil {
-		return "", errInvalidPolicy{err: fmt.Errorf("invalid policy: %w", err)}
+		return policyResult{}, errInvalidPolicy{err: fmt.Errorf("invalid policy: %w", err)}
 	}
 
-	// Read tags from database with a maximum of 3 retries.
-	tags, err := r.listTagsWithBackoff(ctx, storage.RepoIdentity{Namespace: repo.Namespace, Name: repo.Name, CanonicalName: repo.Status.CanonicalImageName})
+	// Read tags from database with a maximum of 3 retries. The repository
+	// may have no tags yet when mirrors supply them, so it is read once.
+	repoID := storage.RepoIdentity{Namespace: repo.Namespace, Name: repo.Name, CanonicalName: repo.Status.CanonicalImageName}
+	var tags []string
+	if len(obj.Spec.MirrorRepositoryRefs) == 0 {
+		tags, err = r.listTagsWithBackoff(ctx, repoID)
+	} else if tags, err = r.Database.Tags(ctx, repoID); err != nil {
+		err = fmt.Errorf("failed to read tags from database: %w", err)
+	}
 	if err != nil {
-		return "", err
+		return policyResult{}, err
+	}
+
+	// Add the tags of the mirror repositories, remembering the first one
+	// listing each tag missing from the repository.
+	mirrors, err := r.getMirrorRepositories(ctx, obj)
+	if err != nil {
+		return policyResult{}, err
+	}
+	images := []string{repo.Spec.Image}
+	var sources map[string]*imagev1.ImageRepository
+	if len(mirrors) > 0 {
+		tags = slices.Clone(tags)
+		sources = make(map[string]*imagev1.ImageRepository)
+		listed := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			listed[tag] = true
+		}
+		for _, mirror := range mirrors {
+			images = append(images, mirror.Spec.Image)
+			mirrorID := storage.RepoIdentity{Namespace: mirror.Namespace, Name: mirror.Name, CanonicalName: mirror.Status.CanonicalImageName}
+			mirrorTags, err := r.Database.Tags(ctx, mirrorID)
+			if err != nil {
+				return policyResult{}, fmt.Errorf("failed to read tags of the mirror ImageRepository %s/%s from database: %w",
+					mirror.Namespace, mirror.Name, err)
+			}
+			for _, tag := range mirrorTags {
+				if !listed[tag] {
+					listed[tag] = true
+					sources[tag] = mirror
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return policyResult{}, errNoTagsInDatabase
+	}
+	allTags := tags
+
+	// Keep the tags listed in all the lockstep repositories.
+	if len(obj.Spec.LockstepRepositoryRefs) > 0 {
+		if tags, err = r.intersectLockstepTags(ctx, obj, tags); err != nil {
+			return policyResult{}, err
+		}
+	}
+
+	// Pin the tag if it is listed, regardless of the policy. The tag elected
+	// by the policy without the pin is kept for reporting it, and failing to
+	// elect one does not prevent pinning the tag, which has to work during
+	// incidents.
+	if pin, _, _ := strings.Cut(obj.Spec.Pin, "@"); pin != "" {
+		if !slices.Contains(allTags, pin) {
+			return policyResult{}, errInvalidPolicy{
+				err: fmt.Errorf("pinned tag %s is not listed in the image repository", pin),
+			}
+		}
+		unpinned := obj.DeepCopy()
+		unpinned.Spec.Pin = ""
+		res, err := r.applyPolicy(ctx, unpinned, repo)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Info("no tag elected by the policy of the pinned ImagePolicy", "error", err.Error())
+			res = policyResult{sources: sources}
+		}
+		return res.pin(pin, policer), nil
+	}
+
+	// Read the tag history at most once, when needed.
+	now := time.Now()
+	var firstSeen map[string]time.Time
+	readFirstSeen := func() (map[string]time.Time, error) {
+		if firstSeen != nil {
+			return firstSeen, nil
+		}
+		seen, err := r.Database.FirstSeen(ctx, repoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tag history from database: %w", err)
+		}
+		firstSeen = seen
+		if firstSeen == nil {
+			firstSeen = map[string]time.Time{}
+		}
+		// Tags are first seen when the first repository lists them.
+		for _, mirror := range mirrors {
+			mirrorID := storage.RepoIdentity{Namespace: mirror.Namespace, Name: mirror.Name, CanonicalName: mirror.Status.CanonicalImageName}
+			seen, err := r.Database.FirstSeen(ctx, mirrorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read tag history from database: %w", err)
+			}
+			for tag, t := range seen {
+				if prev, ok := firstSeen[tag]; !ok || t.Before(prev) {
+					firstSeen[tag] = t
+				}
+			}
+		}
+		return firstSeen, nil
 	}
 
 	// Apply tag filter.
+	original := func(tag string) string { return tag }
+	var filter *policy.RegexFilter
 	if obj.Spec.FilterTags != nil {
-		filter, err := policy.NewRegexFilter(obj.Spec.FilterTags.Pattern, obj.Spec.FilterTags.Extract)
+		filter, err = policy.FilterFromSpec(*obj.Spec.FilterTags)
 		if err != nil {
-			return "", errInvalidPolicy{err: fmt.Errorf("failed to filter tags: %w", err)}
+			return policyResult{}, errInvalidPolicy{err: fmt.Errorf("failed to filter tags: %w", err)}
+		}
+		if filter.Expression != nil || filter.Collision == policy.CollisionStrategyNewestFirstSeen {
+			seen, err := readFirstSeen()
+			if err != nil {
+				return policyResult{}, err
+			}
+			filter.SetFirstSeen(seen, now)
+		}
+		if filter.Expression != nil {
+			env := policy.TagEnv{Name: obj.Name, Namespace: obj.Namespace, FirstSeen: firstSeen, Now: now}
+			if tags, err = filter.Select(ctx, tags, env); err != nil {
+				return policyResult{}, fmt.Errorf("failed to filter tags: %w", err)
+			}
 		}
 		filter.Apply(tags)
 		tags = filter.Items()
-		latest, err := policer.Latest(tags)
+		original = filter.GetOriginalTag
+	}
+
+	// Look up the image creation times if the policy orders by them.
+	if p, ok := policer.(*policy.CreationTime); ok {
+		originals := make(map[*imagev1.ImageRepository][]string)
+		for _, tag := range tags {
+			source := tagSource(repo, sources, original(tag))
+			originals[source] = append(originals[source], original(tag))
+		}
+		times := make(map[string]time.Time, len(tags))
+		for source, sourceTags := range originals {
+			sourceTimes, err := r.fetchCreationTimes(ctx, source, obj, sourceTags)
+			if err != nil {
+				return policyResult{}, err
+			}
+			maps.Copy(times, sourceTimes)
+		}
+		created := make(map[string]time.Time, len(tags))
+		for _, tag := range tags {
+			created[tag] = times[original(tag)]
+		}
+		// Look up the creation time of the current latest image by its
+		// digest if its tag is not listed anymore, so that it can still be
+		// compared with the new one.
+		if current, ok := r.currentValue(obj, images, filter); ok && !slices.Contains(allTags, obj.Status.LatestRef.Tag) {
+			t, err := r.cachedCreationTime(ctx, append([]*imagev1.ImageRepository{repo}, mirrors...), obj.Status.LatestRef)
+			if err != nil {
+				return policyResult{}, err
+			}
+			if !t.IsZero() {
+				created[current] = t
+			}
+		}
+		p.SetCreationTimes(created)
+	}
+
+	// Restrict the tags to the bounds set by other policies.
+	if obj.Spec.CeilingPolicyRef != nil || obj.Spec.FloorPolicyRef != nil {
+		if tags, err = r.applyBounds(ctx, obj, policer, filter, tags); err != nil {
+			return policyResult{}, err
+		}
+	}
+
+	// Compute the result.
+	latest, err := policer.Latest(tags)
+	if err != nil {
+		return policyResult{}, err
+	}
+	res := policyResult{latest: original(latest), sources: sources}
+
+	// Report the current latest tag if it is not listed anymore.
+	if current := obj.Status.LatestRef; current != nil && slices.Contains(images, current.Name) &&
+		current.Tag != "" && !slices.Contains(allTags, current.Tag) {
+		res.removed = current.Tag
+	}
+
+	// Only elect tags which have been observed for longer than the minimum
+	// age. Tags not recorded yet are considered as first seen now, except the
+	// current latest tag which stays eligible, as the history is empty when
+	// the minimum age is set on an existing policy.
+	if obj.Spec.MinimumAge != nil && obj.Spec.MinimumAge.Duration > 0 {
+		firstSeen, err := readFirstSeen()
+		if err != nil {
+			return policyResult{}, err
+		}
+		var current string
+		if ref := obj.Status.LatestRef; ref != nil && slices.Contains(images, ref.Name) {
+			current = ref.Tag
+		}
+		eligibleAt := func(tag string) time.Time {
+			if original(tag) == current {
+				return time.Time{}
+			}
+			seen, ok := firstSeen[original(tag)]
+			if !ok {
+				seen = now
+			}
+			return seen.Add(obj.Spec.MinimumAge.Duration)
+		}
+
+		if eligibleAt(latest).After(now) {
+			res.latest, res.soaking, res.eligibleAt = "", original(latest), eligibleAt(latest)
+			var eligible []string
+			for _, tag := range tags {
+				if !eligibleAt(tag).After(now) {
+					eligible = append(eligible, tag)
+				}
+			}
+			if len(eligible) == 0 {
+				return res, nil
+			}
+			latest, err = policer.Latest(eligible)
+			if err != nil {
+				return policyResult{}, err
+			}
+			res.latest = original(latest)
+			tags = eligible
+		}
+	}
+
+	// Keep the removed latest tag unless the new one ranks higher, in which
+	// case the removal is not worth reporting. The removed tag is also kept
+	// if it cannot be compared anymore, e.g. its creation time is unknown.
+	if res.removed != "" && obj.GetLatestTagRemovalPolicy() == imagev1.LatestTagRemovalKeep {
+		current, ok := r.currentValue(obj, images, filter)
+		if c, comparable := compareTags(policer, latest, current); ok && (!comparable || c < 0) {
+			res.latest = res.removed
+			latest = current
+		} else {
+			res.removed = ""
+		}
+	}
+
+	// Keep the current latest tag if the new one ranks lower, comparing them
+	// with the policy regardless of its range. A removed latest tag which
+	// cannot be compared anymore is kept too, as the new tag may be older.
+	if obj.Spec.PreventDowngrade {
+		if current, ok := r.currentValue(obj, images, filter); ok && current != latest {
+			if c, comparable := compareTags(policer, latest, current); (comparable && c < 0) ||
+				(!comparable && res.removed != "") {
+				res.rejected = res.latest
+				res.latest = obj.Status.LatestRef.Tag
+				latest = current
+			}
+		}
+	}
+
+	// Report the tags sharing the value extracted from the latest one, and
+	// reject them if the collision strategy says so.
+	if filter != nil {
+		if collisions := filter.Collisions(latest); collisions != nil {
+			res.collision = &imagev1.ExtractCollision{Value: latest, Tags: collisions}
+		}
+		if err := filter.CheckCollision(latest); err != nil {
+			return policyResult{}, errTagCollision{err: err, collision: res.collision}
+		}
+	}
+
+	// Rank the candidates among the tags the latest one was elected from.
+	if obj.Spec.Candidates != nil {
+		candidates := []string{latest}
+		if ranker, ok := policer.(policy.Ranker); ok {
+			if candidates, err = ranker.Rank(tags); err != nil {
+				return policyResult{}, err
+			}
+		}
+		for i := 0; i < len(candidates) && i < obj.Spec.Candidates.Count; i++ {
+			res.candidates = append(res.candidates, original(candidates[i]))
+		}
+	}
+
+	// Elect the latest tag of each release track, if configured.
+	if tracker, ok := policer.(policy.Tracker); ok {
+		tracks, err := tracker.LatestByTrack(tags)
+		if err != nil {
+			return policyResult{}, err
+		}
+		for track, tag := range tracks {
+			if res.tracks == nil {
+				res.tracks = make(map[string]string, len(tracks))
+			}
+			res.tracks[track] = original(tag)
+		}
+	}
+
+	// List the versioned tags the floating tag may be resolved to.
+	if ft := obj.Spec.Policy.FloatingTag; ft != nil && ft.ResolveVersion != nil {
+		if res.versions, err = resolvableVersions(*ft.ResolveVersion, repo, res.sources, res.latest, allTags); err != nil {
+			return policyResult{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// defaultResolveMaxTags is the default maximum number of versioned tags whose
+// digest is compared with the one of a floating tag.
+const defaultResolveMaxTags = 10
+
+// resolvableVersions returns the highest versions within the range of the
+// given resolution, among the tags listed in the same repository as the
+// floating one.
+func resolvableVersions(resolution imagev1.VersionResolution, repo *imagev1.ImageRepository,
+	sources map[string]*imagev1.ImageRepository, floating string, tags []string) ([]string, error) {
+
+	r := resolution.Range
+	if r == "" {
+		r = "*"
+	}
+	policer, err := policy.NewSemVer(r)
+	if err != nil {
+		return nil, errInvalidPolicy{err: fmt.Errorf("invalid version resolution: %w", err)}
+	}
+
+	source := tagSource(repo, sources, floating)
+	var listed []string
+	for _, tag := range tags {
+		if tag != floating && tagSource(repo, sources, tag) == source {
+			listed = append(listed, tag)
+		}
+	}
+	if len(listed) == 0 {
+		return nil, nil
+	}
+	versions, err := policer.Rank(listed)
+	if err != nil {
+		// None of the tags is a version within the range.
+		return nil, nil
+	}
+
+	maxTags := resolution.MaxTags
+	if maxTags <= 0 {
+		maxTags = defaultResolveMaxTags
+	}
+	if len(versions) > maxTags {
+		versions = versions[:maxTags]
+	}
+	return versions, nil
+}
+
+// getMirrorRepositories tries to fetch the mirror ImageRepositories of the
+// given ImagePolicy if they're accessible.
+func (r *ImagePolicyReconciler) getMirrorRepositories(ctx context.Context, obj *imagev1.ImagePolicy) ([]*imagev1.ImageRepository, error) {
+	mirrors := make([]*imagev1.ImageRepository, 0, len(obj.Spec.MirrorRepositoryRefs))
+	for _, ref := range obj.Spec.MirrorRepositoryRefs {
+		mirror, err := r.getReferencedImageRepository(ctx, obj, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the mirror ImageRepository: %w", err)
+		}
+		mirrors = append(mirrors, mirror)
+	}
+	return mirrors, nil
+}
+
+// intersectLockstepTags returns the given tags which are also listed in all
+// the lockstep repositories of the given ImagePolicy.
+func (r *ImagePolicyReconciler) intersectLockstepTags(ctx context.Context,
+	obj *imagev1.ImagePolicy, tags []string) ([]string, error) {
+
+	for _, ref := range obj.Spec.LockstepRepositoryRefs {
+		repo, err := r.getReferencedImageRepository(ctx, obj, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the lockstep ImageRepository: %w", err)
+		}
+		repoID := storage.RepoIdentity{Namespace: repo.Namespace, Name: repo.Name, CanonicalName: repo.Status.CanonicalImageName}
+		lockstepTags, err := r.listTagsWithBackoff(ctx, repoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the tags of the lockstep ImageRepository %s/%s: %w",
+				repo.Namespace, repo.Name, err)
+		}
+		tags = slices.DeleteFunc(slices.Clone(tags), func(tag string) bool {
+			return !slices.Contains(lockstepTags, tag)
+		})
+	}
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("no tag is listed in all the lockstep image repositories")
+	}
+	return tags, nil
+}
+
+// applyBounds returns the tags ranking at most as high as the latest tag of
+// the ceiling policy, and at least as high as the latest tag of the floor
+// policy, according to the given policy.
+func (r *ImagePolicyReconciler) applyBounds(ctx context.Context, obj *imagev1.ImagePolicy,
+	policer policy.Policer, filter *policy.RegexFilter, tags []string) ([]string, error) {
+
+	bound := func(ref *meta.NamespacedObjectReference) (string, error) {
+		if ref == nil {
+			return "", nil
+		}
+		tag, err := r.getBoundTag(ctx, obj, *ref)
 		if err != nil {
 			return "", err
 		}
-		return filter.GetOriginalTag(latest), nil
+		value := tag
+		if filter != nil {
+			var ok bool
+			if value, ok = filter.ExtractValue(tag); !ok {
+				return "", fmt.Errorf("latest tag %s of %s %s does not match the tag filter",
+					tag, imagev1.ImagePolicyKind, boundPolicyName(obj, *ref))
+			}
+		}
+		if _, ok := compareTags(policer, value, value); !ok {
+			return "", fmt.Errorf("latest tag %s of %s %s cannot be ordered by the policy",
+				tag, imagev1.ImagePolicyKind, boundPolicyName(obj, *ref))
+		}
+		return value, nil
+	}
+	ceiling, err := bound(obj.Spec.CeilingPolicyRef)
+	if err != nil {
+		return nil, err
+	}
+	floor, err := bound(obj.Spec.FloorPolicyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var bounded []string
+	for _, tag := range tags {
+		if ceiling != "" {
+			if c, ok := compareTags(policer, tag, ceiling); !ok || c > 0 {
+				continue
+			}
+		}
+		if floor != "" {
+			if c, ok := compareTags(policer, tag, floor); !ok || c < 0 {
+				continue
+			}
+		}
+		bounded = append(bounded, tag)
+	}
+	if len(bounded) == 0 {
+		var bounds []string
+		if ceiling != "" {
+			bounds = append(bounds, "at most as high as "+ceiling)
+		}
+		if floor != "" {
+			bounds = append(bounds, "at least as high as "+floor)
+		}
+		return nil, fmt.Errorf("no tag ranks %s", strings.Join(bounds, " and "))
+	}
+	return bounded, nil
+}
+
+// compareTags compares two tags with the given policy regardless of its
+// range, so that tags the policy would not elect can still be ordered. It
+// returns false if either of them cannot be ordered.
+func compareTags(policer policy.Policer, a, b string) (int, bool) {
+	comparer, ok := policer.(policy.Comparer)
+	if !ok {
+		return 0, false
+	}
+	return comparer.Compare(a, b)
+}
+
+// getBoundTag returns the latest tag of the ImagePolicy bounding the given
+// one if it's accessible.
+func (r *ImagePolicyReconciler) getBoundTag(ctx context.Context, obj *imagev1.ImagePolicy,
+	ref meta.NamespacedObjectReference) (string, error) {
+
+	name := boundPolicyName(obj, ref)
+	if name == client.ObjectKeyFromObject(obj) {
+		return "", errInvalidPolicy{err: fmt.Errorf("%s cannot be bounded by itself", imagev1.ImagePolicyKind)}
+	}
+
+	// If NoCrossNamespaceRefs is true and the ImagePolicies are in different
+	// namespaces, the bound ImagePolicy can't be accessed.
+	if r.ACLOptions.NoCrossNamespaceRefs && name.Namespace != obj.GetNamespace() {
+		return "", errAccessDenied{
+			err: fmt.Errorf("cannot access '%s/%s', cross-namespace references have been blocked", imagev1.ImagePolicyKind, name),
+		}
+	}
+
+	bound := &imagev1.ImagePolicy{}
+	if err := r.Get(ctx, name, bound); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("referenced %s %s does not exist: %w", imagev1.ImagePolicyKind, name, errBoundPolicyNotReady)
+		}
+		return "", err
+	}
+	if bound.Status.LatestRef == nil || bound.Status.LatestRef.Tag == "" {
+		return "", fmt.Errorf("referenced %s %s: %w", imagev1.ImagePolicyKind, name, errBoundPolicyNotReady)
+	}
+	return bound.Status.LatestRef.Tag, nil
+}
+
+// boundPolicyName returns the name of the ImagePolicy referenced as a bound by
+// the given one.
+func boundPolicyName(obj client.Object, ref meta.NamespacedObjectReference) types.NamespacedName {
+	name := types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      ref.Name,
+	}
+	if ref.Namespace != "" {
+		name.Namespace = ref.Namespace
+	}
+	return name
+}
+
+// currentValue returns the value of the current latest tag to compare with
+// the new one, i.e. the value extracted from it if a filter is set. It
+// reports false if there is no current latest tag for the image, or if it
+// does not pass the filter anymore.
+func (r *ImagePolicyReconciler) currentValue(obj *imagev1.ImagePolicy,
+	images []string, filter *policy.RegexFilter) (string, bool) {
+
+	current := obj.Status.LatestRef
+	if current == nil || !slices.Contains(images, current.Name) || current.Tag == "" {
+		return "", false
+	}
+	if filter == nil {
+		return current.Tag, true
+	}
+	return filter.ExtractValue(current.Tag)
+}
+
+// cachedCreationTime returns the creation time of the given image found in
+// the metadata cache by its digest, looking it up in the given repository
+// which has the name of the image, or the zero time if it is not cached. The
+// image is recorded for its tag, so that its metadata is not pruned while it
+// is looked up.
+func (r *ImagePolicyReconciler) cachedCreationTime(ctx context.Context,
+	repos []*imagev1.ImageRepository, ref *imagev1.ImageRef) (time.Time, error) {
+
+	if r.MetadataCache == nil || ref.Digest == "" {
+		return time.Time{}, nil
+	}
+	i := slices.IndexFunc(repos, func(repo *imagev1.ImageRepository) bool { return repo.Spec.Image == ref.Name })
+	if i < 0 {
+		return time.Time{}, nil
+	}
+	repoID := storage.RepoIdentity{Namespace: repos[i].Namespace, Name: repos[i].Name, CanonicalName: repos[i].Status.CanonicalImageName}
+	metadata, err := r.MetadataCache.Metadata(ctx, repoID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read image metadata from database: %w", err)
+	}
+	image, ok := metadata.Images[ref.Digest]
+	if !ok {
+		return time.Time{}, nil
+	}
+	if metadata.TagDigests[ref.Tag] != ref.Digest {
+		update := storage.RepoMetadata{TagDigests: map[string]string{ref.Tag: ref.Digest}}
+		if err := r.MetadataCache.SetMetadata(ctx, repoID, update); err != nil {
+			return time.Time{}, fmt.Errorf("failed to write image metadata to database: %w", err)
+		}
+	}
+	return image.Created, nil
+}
+
+// fetchCreationTimes fetches the creation time of the images the given tags
+// point to. The tags are resolved to their manifest digest once per scan of
+// the repository, so that tags pushed again are taken into account, and the
+// creation time is only fetched from the registry for new manifest digests.
+// Tags which cannot be resolved are left out, unless none of the tags can
+// be, and an error is returned if the registry requests time out so that the
+// latest tag is not elected from a partial result.
+func (r *ImagePolicyReconciler) fetchCreationTimes(ctx context.Context,
+	repo *imagev1.ImageRepository, obj *imagev1.ImagePolicy, tags []string) (map[string]time.Time, error) {
+
+	repoID := storage.RepoIdentity{Namespace: repo.Namespace, Name: repo.Name, CanonicalName: repo.Status.CanonicalImageName}
+	var metadata storage.RepoMetadata
+	if r.MetadataCache != nil {
+		var err error
+		if metadata, err = r.MetadataCache.Metadata(ctx, repoID); err != nil {
+			return nil, fmt.Errorf("failed to read image metadata from database: %w", err)
+		}
+	}
+	created := make(map[string]time.Time, len(tags))
+	var unresolved []string
+	for _, tag := range tags {
+		if image, ok := metadata.Images[metadata.TagDigests[tag]]; ok {
+			created[tag] = image.Created
+			continue
+		}
+		unresolved = append(unresolved, tag)
+	}
+	if len(unresolved) == 0 {
+		return created, nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, repo.GetTimeout())
+	defer cancel()
+	opts, err := r.remoteOptions(fetchCtx, repo, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	update := storage.RepoMetadata{
+		TagDigests: make(map[string]string, len(unresolved)),
+		Images:     make(map[string]storage.ImageMetadata),
+	}
+	var errs []error
+	skip := func(tag string, err error) {
+		log.Info("skipping tag without image creation time", "tag", tag, "error", err.Error())
+		errs = append(errs, err)
+	}
+	for _, tag := range unresolved {
+		tagRef, err := tagReference(repo, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		head, err := remote.Head(tagRef, opts...)
+		if fetchCtx.Err() != nil {
+			break
+		}
+		if err != nil {
+			skip(tag, fmt.Errorf("failed fetching descriptor for %q: %w", tagRef.String(), err))
+			continue
+		}
+		digest := head.Digest.String()
+
+		image, ok := metadata.Images[digest]
+		if !ok {
+			image, ok = update.Images[digest]
+		}
+		if !ok {
+			desc, err := remote.Get(tagRef.Context().Digest(digest), opts...)
+			if fetchCtx.Err() != nil {
+				break
+			}
+			if err != nil {
+				skip(tag, fmt.Errorf("failed fetching manifest for %q: %w", tagRef.String(), err))
+				continue
+			}
+			t, err := registry.ImageCreated(desc)
+			if err != nil {
+				skip(tag, fmt.Errorf("failed reading creation time of %q: %w", tagRef.String(), err))
+				continue
+			}
+			image = storage.ImageMetadata{Created: t}
+			update.Images[digest] = image
+		}
+		created[tag] = image.Created
+		update.TagDigests[tag] = digest
+	}
+
+	// Record the resolved tags even if the registry requests timed out, so
+	// that they are not requested again on the next attempt.
+	if r.MetadataCache != nil && len(update.TagDigests) > 0 {
+		if err := r.MetadataCache.SetMetadata(ctx, repoID, update); err != nil {
+			return nil, fmt.Errorf("failed to write image metadata to database: %w", err)
+		}
 	}
-	// Compute and return result.
-	return policer.Latest(tags)
+	if err := fetchCtx.Err(); err != nil {
+		return nil, fmt.Errorf("failed fetching image creation times for %q: %w", repo.Spec.Image, err)
+	}
+	if len(created) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return created, nil
 }
 
 // reconcileDelete handles the deletion of the object.
@@ -575,6 +1836,21 @@ func (r *ImagePolicyReconciler) imagePoliciesForRepository(ctx context.Context,
 	return reqs
 }
 
+func (r *ImagePolicyReconciler) imagePoliciesForBoundPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+	var policies imagev1.ImagePolicyList
+	if err := r.List(ctx, &policies, client.MatchingFields{boundPolicyKey: client.ObjectKeyFromObject(obj).String()}); err != nil {
+		log.Error(err, "failed to list ImagePolicies bounded by the same")
+		return nil
+	}
+	reqs := make([]reconcile.Request, len(policies.Items))
+	for i := range policies.Items {
+		reqs[i].NamespacedName.Name = policies.Items[i].GetName()
+		reqs[i].NamespacedName.Namespace = policies.Items[i].GetNamespace()
+	}
+	return reqs
+}
+
 // listTagsWithBackoff lists the tags of the given image from the
 // internal database with retries if there are no tags in the database.
 func (r *ImagePolicyReconciler) listTagsWithBackoff(ctx context.Context, repo storage.RepoIdentity) ([]string, error) {
@@ -19,10 +19,18 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -46,6 +54,7 @@ import (
 	imagev1 "github.com/fluxcd/image-reflector-controller/api/v1"
 	"github.com/fluxcd/image-reflector-controller/internal/policy"
 	"github.com/fluxcd/image-reflector-controller/internal/registry"
+	"github.com/fluxcd/image-reflector-controller/internal/storage"
 	"github.com/fluxcd/image-reflector-controller/internal/test"
 )
 
@@ -867,6 +876,191 @@ func TestImagePolicyReconciler_fetchDigest_respectsContext(t *testing.T) {
 	g.Expect(err.Error()).To(ContainSubstring("context canceled"))
 }
 
+func TestImagePolicyReconciler_applyPolicy_creationTime(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo := test.RegistryName(registryServer) + "/foo/bar"
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	created := map[string]time.Time{
+		"main-aaa": base.Add(time.Hour),
+		"main-bbb": base.Add(2 * time.Hour),
+		"main-ccc": base,
+	}
+	for tag, ts := range created {
+		img, err := random.Image(512, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		img, err = mutate.CreatedAt(img, v1.Time{Time: ts})
+		g.Expect(err).ToNot(HaveOccurred())
+		ref, err := name.NewTag(imgRepo + ":" + tag)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remote.Write(ref, img)).To(Succeed())
+	}
+
+	db := &mockDatabase{TagData: []string{"main-aaa", "main-bbb", "main-ccc", "latest-ddd"}}
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		Database:          db,
+		MetadataCache:     db,
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = imgRepo
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{CreationTime: &imagev1.CreationTimePolicy{}}
+	obj.Spec.FilterTags = &imagev1.TagFilter{Pattern: `^main-(?P<sha>[a-z]+)$`, Extract: "$sha"}
+
+	res, err := r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("main-bbb"))
+	g.Expect(db.MetadataData).To(HaveLen(3))
+
+	// The cached creation times take precedence over the registry.
+	for digest, metadata := range db.MetadataData {
+		if metadata.Created.Equal(base) {
+			db.MetadataData[digest] = storage.ImageMetadata{Created: base.Add(3 * time.Hour)}
+		}
+	}
+	res, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("main-ccc"))
+
+	// The tags are resolved once, so the registry is not requested again.
+	g.Expect(db.DigestData).To(HaveLen(3))
+	registryServer.Close()
+	res, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("main-ccc"))
+}
+
+func TestImagePolicyReconciler_applyPolicy_creationTimePushedAgain(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo := test.RegistryName(registryServer) + "/foo/bar"
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	push := func(tag string, created time.Time) {
+		img, err := random.Image(512, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		img, err = mutate.CreatedAt(img, v1.Time{Time: created})
+		g.Expect(err).ToNot(HaveOccurred())
+		ref, err := name.NewTag(imgRepo + ":" + tag)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remote.Write(ref, img)).To(Succeed())
+	}
+	push("main", base)
+	push("dev", base.Add(time.Hour))
+
+	db := &mockDatabase{}
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		Database:          db,
+		MetadataCache:     db,
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Name = "repo"
+	repo.Namespace = "default"
+	repo.Spec.Image = imgRepo
+	repoID := storage.RepoIdentity{Namespace: repo.Namespace, Name: repo.Name}
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{CreationTime: &imagev1.CreationTimePolicy{}}
+
+	_, err := db.SetTags(context.Background(), repoID, []string{"main", "dev"})
+	g.Expect(err).ToNot(HaveOccurred())
+	res, err := r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("dev"))
+
+	// The tags are resolved once per scan of the repository.
+	push("main", base.Add(2*time.Hour))
+	res, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("dev"))
+
+	db.TagData = nil
+	_, err = db.SetTags(context.Background(), repoID, []string{"main", "dev"})
+	g.Expect(err).ToNot(HaveOccurred())
+	res, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("main"))
+	g.Expect(db.MetadataData).To(HaveLen(3))
+}
+
+func TestImagePolicyReconciler_applyPolicy_creationTimeUnresolvedTags(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo := test.RegistryName(registryServer) + "/foo/bar"
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, tag := range []string{"main-aaa", "main-bbb"} {
+		img, err := random.Image(512, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		img, err = mutate.CreatedAt(img, v1.Time{Time: base.Add(time.Duration(i) * time.Hour)})
+		g.Expect(err).ToNot(HaveOccurred())
+		ref, err := name.NewTag(imgRepo + ":" + tag)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remote.Write(ref, img)).To(Succeed())
+	}
+
+	db := &mockDatabase{}
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		Database:          db,
+		MetadataCache:     db,
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = imgRepo
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{CreationTime: &imagev1.CreationTimePolicy{}}
+
+	// The tags missing from the registry are left out.
+	db.TagData = []string{"main-aaa", "main-bbb", "main-ccc"}
+	res, err := r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("main-bbb"))
+	g.Expect(db.DigestData).To(HaveKey("main-aaa"))
+	g.Expect(db.DigestData).To(HaveKey("main-bbb"))
+	g.Expect(db.DigestData).ToNot(HaveKey("main-ccc"))
+
+	// The policy fails if none of the tags can be resolved.
+	db.TagData = []string{"main-ccc", "main-ddd"}
+	_, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("main-ccc"))
+	g.Expect(err.Error()).To(ContainSubstring("main-ddd"))
+
+	// The policy fails if the registry requests time out.
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slowServer.Close()
+	repo.Spec.Image = test.RegistryName(slowServer) + "/foo/bar"
+	repo.Spec.Timeout = &metav1.Duration{Duration: 100 * time.Millisecond}
+	_, err = r.applyPolicy(context.Background(), obj, repo)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("context deadline exceeded"))
+}
+
 func TestImagePolicyReconciler_digestReflection(t *testing.T) {
 	registryServer := test.NewRegistryServer()
 	defer registryServer.Close()
@@ -1260,6 +1454,12 @@ func TestImagePolicyReconciler_applyPolicy(t *testing.T) {
 			db:         &mockDatabase{TagData: []string{"v1.0.0", "v2.0.0", "v1.0.1", "v1.2.0"}},
 			wantResult: "v1.0.1",
 		},
+		{
+			name:       "calver, no tag filter",
+			policy:     imagev1.ImagePolicyChoice{CalVer: &imagev1.CalVerPolicy{Format: "YYYY.MM.MICRO", Range: ">=2024"}},
+			db:         &mockDatabase{TagData: []string{"2023.12.1", "2024.9.10", "2024.10.1", "latest"}},
+			wantResult: "2024.10.1",
+		},
 		{
 			name:    "invalid tag filter",
 			policy:  imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: "1.0.x"}},
@@ -1291,6 +1491,33 @@ func TestImagePolicyReconciler_applyPolicy(t *testing.T) {
 			}},
 			wantResult: "foo-zzz",
 		},
+		{
+			name:   "valid tag filter with sort keys",
+			policy: imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}},
+			filter: &imagev1.TagFilter{
+				Pattern: `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+				Extract: "$version",
+				SortKeys: []imagev1.TagSortKey{
+					{Group: "build", Type: policy.SortKeyTypeNumeric},
+				},
+			},
+			db: &mockDatabase{TagData: []string{
+				"1.4.2-build.37", "1.4.2-build.9", "1.4.1-build.99",
+			}},
+			wantResult: "1.4.2-build.37",
+		},
+		{
+			name:   "invalid sort keys",
+			policy: imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}},
+			filter: &imagev1.TagFilter{
+				Pattern: `^(?P<version>[0-9.]+)$`,
+				SortKeys: []imagev1.TagSortKey{
+					{Group: "build", Type: policy.SortKeyTypeNumeric},
+				},
+			},
+			db:      &mockDatabase{TagData: []string{"1.4.2"}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1317,96 +1544,1629 @@ func TestImagePolicyReconciler_applyPolicy(t *testing.T) {
 			result, err := r.applyPolicy(ctx, obj, repo)
 			g.Expect(err != nil).To(Equal(tt.wantErr))
 			if err == nil {
-				g.Expect(result).To(Equal(tt.wantResult))
+				g.Expect(result.latest).To(Equal(tt.wantResult))
 			}
 		})
 	}
 }
 
-func TestComposeImagePolicyReadyMessage(t *testing.T) {
+func TestImagePolicyReconciler_applyPolicy_minimumAge(t *testing.T) {
+	now := time.Now()
+	tags := []string{"1.0.0", "1.1.0", "1.2.0"}
+
 	tests := []struct {
-		name        string
-		obj         *imagev1.ImagePolicy
-		wantMessage string
+		name           string
+		firstSeen      map[string]time.Time
+		current        string
+		wantLatest     string
+		wantSoaking    string
+		wantEligibleAt time.Time
 	}{
 		{
-			name: "no previous tag",
-			obj: &imagev1.ImagePolicy{
-				Status: imagev1.ImagePolicyStatus{
-					LatestRef: &imagev1.ImageRef{
-						Name: "foo/bar",
-						Tag:  "1.0.0",
-					},
-				},
+			name: "latest tag is old enough",
+			firstSeen: map[string]time.Time{
+				"1.0.0": now.Add(-3 * time.Hour),
+				"1.1.0": now.Add(-2 * time.Hour),
+				"1.2.0": now.Add(-2 * time.Hour),
 			},
-			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0",
+			wantLatest: "1.2.0",
 		},
 		{
-			name: "different previous tag",
-			obj: &imagev1.ImagePolicy{
-				Status: imagev1.ImagePolicyStatus{
-					LatestRef: &imagev1.ImageRef{
-						Name: "foo/bar",
-						Tag:  "1.1.0",
-					},
-					ObservedPreviousRef: &imagev1.ImageRef{
-						Name: "foo/bar",
-						Tag:  "1.0.0",
-					},
-				},
+			name: "latest tag is soaking",
+			firstSeen: map[string]time.Time{
+				"1.0.0": now.Add(-3 * time.Hour),
+				"1.1.0": now.Add(-2 * time.Hour),
+				"1.2.0": now.Add(-30 * time.Minute),
 			},
-			wantMessage: "Latest image tag for foo/bar resolved to 1.1.0 (previously foo/bar:1.0.0)",
+			wantLatest:     "1.1.0",
+			wantSoaking:    "1.2.0",
+			wantEligibleAt: now.Add(30 * time.Minute),
 		},
 		{
-			name: "same previous and latest tags",
-			obj: &imagev1.ImagePolicy{
-				Status: imagev1.ImagePolicyStatus{
-					LatestRef: &imagev1.ImageRef{
-						Name: "foo/bar",
-						Tag:  "1.0.0",
-					},
-					ObservedPreviousRef: &imagev1.ImageRef{
-						Name: "foo/bar",
-						Tag:  "1.0.0",
-					},
-				},
+			name: "no tag is old enough",
+			firstSeen: map[string]time.Time{
+				"1.0.0": now.Add(-50 * time.Minute),
+				"1.1.0": now.Add(-40 * time.Minute),
+				"1.2.0": now.Add(-30 * time.Minute),
 			},
-			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0",
+			wantSoaking:    "1.2.0",
+			wantEligibleAt: now.Add(30 * time.Minute),
 		},
 		{
-			name: "different image with digest",
-			obj: &imagev1.ImagePolicy{
-				Status: imagev1.ImagePolicyStatus{
-					LatestRef: &imagev1.ImageRef{
-						Name:   "foo/bar",
-						Tag:    "1.0.0",
-						Digest: "sha256:1234567890abcdef",
-					},
-					ObservedPreviousRef: &imagev1.ImageRef{
-						Name: "baz/qux",
-						Tag:  "1.0.0",
-					},
-				},
+			name: "tag not recorded yet",
+			firstSeen: map[string]time.Time{
+				"1.0.0": now.Add(-3 * time.Hour),
+				"1.1.0": now.Add(-2 * time.Hour),
 			},
-			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0 with digest sha256:1234567890abcdef (previously baz/qux:1.0.0)",
+			wantLatest:  "1.1.0",
+			wantSoaking: "1.2.0",
 		},
 		{
-			name: "different digest",
-			obj: &imagev1.ImagePolicy{
-				Status: imagev1.ImagePolicyStatus{
-					LatestRef: &imagev1.ImageRef{
-						Name:   "foo/bar",
-						Tag:    "1.0.0",
-						Digest: "sha256:1234567890abcdef",
-					},
-					ObservedPreviousRef: &imagev1.ImageRef{
-						Name:   "foo/bar",
-						Tag:    "1.0.0",
-						Digest: "sha256:abcdef1234567890",
-					},
-				},
+			name:        "existing latest tag with empty history",
+			current:     "1.1.0",
+			wantLatest:  "1.1.0",
+			wantSoaking: "1.2.0",
+		},
+		{
+			name: "existing latest tag with newer eligible tag",
+			firstSeen: map[string]time.Time{
+				"1.2.0": now.Add(-2 * time.Hour),
 			},
-			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0 with digest sha256:1234567890abcdef (previously foo/bar:1.0.0@sha256:abcdef1234567890)",
+			current:    "1.1.0",
+			wantLatest: "1.2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ImagePolicyReconciler{
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: tags, FirstSeenData: tt.firstSeen},
+			}
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			obj.Spec.MinimumAge = &metav1.Duration{Duration: time.Hour}
+			if tt.current != "" {
+				obj.Status.LatestRef = &imagev1.ImageRef{Tag: tt.current}
+			}
+
+			res, err := r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+			g.Expect(res.soaking).To(Equal(tt.wantSoaking))
+			if !tt.wantEligibleAt.IsZero() {
+				g.Expect(res.eligibleAt).To(BeTemporally("~", tt.wantEligibleAt, time.Second))
+			}
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_candidates(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         imagev1.ImagePolicyChoice
+		filter         *imagev1.TagFilter
+		count          int
+		tags           []string
+		wantCandidates []string
+	}{
+		{
+			name:           "semver",
+			policy:         imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}},
+			count:          3,
+			tags:           []string{"1.0.0", "2.0.0", "1.0.1", "1.2.0", "latest"},
+			wantCandidates: []string{"2.0.0", "1.2.0", "1.0.1"},
+		},
+		{
+			name:           "fewer tags than count",
+			policy:         imagev1.ImagePolicyChoice{Numerical: &imagev1.NumericalPolicy{Order: "asc"}},
+			count:          5,
+			tags:           []string{"1", "3", "2"},
+			wantCandidates: []string{"3", "2", "1"},
+		},
+		{
+			name:   "original tags with filter",
+			policy: imagev1.ImagePolicyChoice{Alphabetical: &imagev1.AlphabeticalPolicy{}},
+			filter: &imagev1.TagFilter{
+				Pattern: `^RELEASE\.(?P<timestamp>.*)Z$`,
+				Extract: "$timestamp",
+			},
+			count:          2,
+			tags:           []string{"RELEASE.2019-01-01T00-00-00Z", "RELEASE.2021-01-01T00-00-00Z", "RELEASE.2020-01-01T00-00-00Z"},
+			wantCandidates: []string{"RELEASE.2021-01-01T00-00-00Z", "RELEASE.2020-01-01T00-00-00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ImagePolicyReconciler{
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: tt.tags},
+			}
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Spec.Policy = tt.policy
+			obj.Spec.FilterTags = tt.filter
+			obj.Spec.Candidates = &imagev1.CandidatesSpec{Count: tt.count}
+
+			res, err := r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.candidates).To(Equal(tt.wantCandidates))
+			g.Expect(res.candidates[0]).To(Equal(res.latest))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_tracks(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ImagePolicyReconciler{
+		EventRecorder: record.NewFakeRecorder(32),
+		Database: &mockDatabase{TagData: []string{
+			"v1.19.9", "v1.20.0", "v1.20.3", "v1.21.0", "v1.21.1", "v2.0.0", "latest",
+		}},
+	}
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.20 <2", Tracks: "minor"}}
+	obj.Spec.FilterTags = &imagev1.TagFilter{Pattern: `^v(?P<version>.*)$`, Extract: "$version"}
+
+	res, err := r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.21.1"))
+	g.Expect(res.tracks).To(Equal(map[string]string{"1.20": "v1.20.3", "1.21": "v1.21.1"}))
+
+	obj.Spec.Policy.SemVer.Tracks = ""
+	res, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.tracks).To(BeNil())
+}
+
+func TestImagePolicyReconciler_applyPolicy_expression(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	r := &ImagePolicyReconciler{
+		EventRecorder: record.NewFakeRecorder(32),
+		Database: &mockDatabase{
+			TagData: []string{"v1.0.0", "v1.1.0-rc.1", "v1.2.0-rc.1", "v1.2.1-debug", "v1.3.0-rc.1"},
+			FirstSeenData: map[string]time.Time{
+				"v1.0.0":       now.Add(-time.Hour),
+				"v1.1.0-rc.1":  now.Add(-time.Hour),
+				"v1.2.0-rc.1":  now.Add(-time.Hour),
+				"v1.2.1-debug": now.Add(-time.Hour),
+			},
+		},
+	}
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Namespace = "default"
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0-0"}}
+	obj.Spec.FilterTags = &imagev1.TagFilter{
+		Pattern:    `^v(?P<version>.*)$`,
+		Extract:    "$version",
+		Expression: `semver.minor % 2 == 0 && (!tag.endsWith("-debug") || policy.namespace == "dev")`,
+	}
+
+	res, err := r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.2.0-rc.1"))
+
+	obj.Namespace = "dev"
+	res, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.2.1-debug"))
+
+	// Tags missing from the history are considered as first seen now.
+	obj.Spec.FilterTags.Expression = fmt.Sprintf(`firstSeen < timestamp("%s")`, now.Add(-time.Minute).Format(time.RFC3339))
+	res, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.2.1-debug"))
+
+	obj.Spec.FilterTags.Expression = `semver.major`
+	_, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.As(err, &errInvalidPolicy{})).To(BeTrue())
+}
+
+func TestImagePolicyReconciler_applyPolicy_collision(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ImagePolicyReconciler{
+		EventRecorder: record.NewFakeRecorder(32),
+		Database: &mockDatabase{
+			TagData: []string{"1.2.3-arm64", "1.2.3-amd64", "1.2.2-amd64"},
+			FirstSeenData: map[string]time.Time{
+				"1.2.3-arm64": time.Now().Add(-time.Minute),
+				"1.2.3-amd64": time.Now().Add(-time.Hour),
+			},
+		},
+	}
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+	obj.Spec.FilterTags = &imagev1.TagFilter{
+		Pattern: `^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`,
+		Extract: "$version",
+	}
+	wantCollision := &imagev1.ExtractCollision{Value: "1.2.3", Tags: []string{"1.2.3-amd64", "1.2.3-arm64"}}
+
+	res, err := r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("1.2.3-amd64"))
+	g.Expect(res.collision).To(Equal(wantCollision))
+
+	obj.Spec.FilterTags.CollisionStrategy = policy.CollisionStrategyNewestFirstSeen
+	res, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("1.2.3-arm64"))
+
+	obj.Spec.FilterTags.CollisionStrategy = policy.CollisionStrategyFail
+	_, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	var collisionErr errTagCollision
+	g.Expect(errors.As(err, &collisionErr)).To(BeTrue())
+	g.Expect(collisionErr.collision).To(Equal(wantCollision))
+
+	obj.Spec.Policy.SemVer.Range = "<1.2.3"
+	res, err = r.applyPolicy(ctx, obj, &imagev1.ImageRepository{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("1.2.2-amd64"))
+	g.Expect(res.collision).To(BeNil())
+}
+
+func TestImagePolicyReconciler_applyPolicy_preventDowngrade(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       *imagev1.TagFilter
+		current      *imagev1.ImageRef
+		disabled     bool
+		wantLatest   string
+		wantRejected string
+	}{
+		{
+			name:         "downgrade prevented",
+			current:      &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			wantLatest:   "v1.2.0",
+			wantRejected: "v1.1.0",
+		},
+		{
+			name:       "downgrade allowed",
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			disabled:   true,
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "upgrade",
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "no current latest tag",
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "current latest tag of another image",
+			current:    &imagev1.ImageRef{Name: "foo/baz", Tag: "v1.2.0"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:         "downgrade prevented with extracted values",
+			filter:       &imagev1.TagFilter{Pattern: `^v(?P<version>.*)$`, Extract: "$version"},
+			current:      &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			wantLatest:   "v1.2.0",
+			wantRejected: "v1.1.0",
+		},
+		{
+			name:       "current latest tag not passing the filter",
+			filter:     &imagev1.TagFilter{Pattern: `^v1\.[01]\.`},
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			wantLatest: "v1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ImagePolicyReconciler{
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = "foo/bar"
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			obj.Spec.FilterTags = tt.filter
+			obj.Spec.PreventDowngrade = !tt.disabled
+			obj.Status.LatestRef = tt.current
+
+			res, err := r.applyPolicy(ctx, obj, repo)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+			g.Expect(res.rejected).To(Equal(tt.wantRejected))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_preventDowngradeCreationTime(t *testing.T) {
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo := test.RegistryName(registryServer) + "/foo/bar"
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for tag, ts := range map[string]time.Time{"main-aaa": base.Add(time.Hour), "main-ccc": base} {
+		img, err := random.Image(512, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img, err = mutate.CreatedAt(img, v1.Time{Time: ts}); err != nil {
+			t.Fatal(err)
+		}
+		ref, err := name.NewTag(imgRepo + ":" + tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := remote.Write(ref, img); err != nil {
+			t.Fatal(err)
+		}
+	}
+	const removedDigest = "sha256:0123456789abcdef"
+
+	tests := []struct {
+		name          string
+		digest        string
+		created       time.Time
+		removalPolicy imagev1.LatestTagRemovalPolicy
+		wantLatest    string
+		wantRejected  string
+	}{
+		{
+			name:         "removed newer image",
+			digest:       removedDigest,
+			created:      base.Add(2 * time.Hour),
+			wantLatest:   "main-bbb",
+			wantRejected: "main-aaa",
+		},
+		{
+			name:       "removed older image",
+			digest:     removedDigest,
+			created:    base.Add(-time.Hour),
+			wantLatest: "main-aaa",
+		},
+		{
+			name:         "removed image without digest",
+			wantLatest:   "main-bbb",
+			wantRejected: "main-aaa",
+		},
+		{
+			name:          "removed image without digest kept",
+			removalPolicy: imagev1.LatestTagRemovalKeep,
+			wantLatest:    "main-bbb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			db := &mockDatabase{TagData: []string{"main-aaa", "main-ccc"}}
+			if tt.digest != "" {
+				db.MetadataData = map[string]storage.ImageMetadata{tt.digest: {Created: tt.created}}
+			}
+			r := &ImagePolicyReconciler{
+				EventRecorder:     record.NewFakeRecorder(32),
+				Database:          db,
+				MetadataCache:     db,
+				AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = imgRepo
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Name = "test"
+			obj.Namespace = "default"
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{CreationTime: &imagev1.CreationTimePolicy{}}
+			obj.Spec.PreventDowngrade = tt.removalPolicy == ""
+			obj.Spec.LatestTagRemovalPolicy = tt.removalPolicy
+			obj.Status.LatestRef = &imagev1.ImageRef{Name: imgRepo, Tag: "main-bbb", Digest: tt.digest}
+
+			res, err := r.applyPolicy(context.Background(), obj, repo)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+			g.Expect(res.rejected).To(Equal(tt.wantRejected))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_removedLatestTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       *imagev1.ImageRef
+		removalPolicy imagev1.LatestTagRemovalPolicy
+		wantLatest    string
+		wantRemoved   string
+	}{
+		{
+			name:        "latest tag removed",
+			current:     &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			wantLatest:  "v1.1.0",
+			wantRemoved: "v1.2.0",
+		},
+		{
+			name:          "removed latest tag kept",
+			current:       &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0"},
+			removalPolicy: imagev1.LatestTagRemovalKeep,
+			wantLatest:    "v1.2.0",
+			wantRemoved:   "v1.2.0",
+		},
+		{
+			name:          "removed latest tag superseded",
+			current:       &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.5"},
+			removalPolicy: imagev1.LatestTagRemovalKeep,
+			wantLatest:    "v1.1.0",
+		},
+		{
+			name:       "latest tag listed",
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "no current latest tag",
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "current latest tag of another image",
+			current:    &imagev1.ImageRef{Name: "foo/baz", Tag: "v1.2.0"},
+			wantLatest: "v1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ImagePolicyReconciler{
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = "foo/bar"
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			obj.Spec.LatestTagRemovalPolicy = tt.removalPolicy
+			obj.Status.LatestRef = tt.current
+
+			res, err := r.applyPolicy(ctx, obj, repo)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+			g.Expect(res.removed).To(Equal(tt.wantRemoved))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_reconcile_removedLatestTagPending(t *testing.T) {
+	g := NewWithT(t)
+
+	s := runtime.NewScheme()
+	utilruntime.Must(imagev1.AddToScheme(s))
+
+	repo := &imagev1.ImageRepository{}
+	repo.Name = "repo"
+	repo.Namespace = "default"
+	repo.Spec.Image = "ghcr.io/foo/bar"
+
+	recorder := record.NewFakeRecorder(32)
+	r := &ImagePolicyReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(s).WithObjects(repo).Build(),
+		EventRecorder: recorder,
+		Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}},
+	}
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.ImageRepositoryRef = meta.NamespacedObjectReference{Name: repo.Name}
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+	obj.Spec.RequireApproval = true
+	obj.Status.LatestRef = &imagev1.ImageRef{Name: repo.Spec.Image, Tag: "v1.2.0"}
+
+	removedEvents := func() int {
+		var n int
+		for {
+			select {
+			case e := <-recorder.Events:
+				if strings.Contains(e, imagev1.LatestTagRemovedReason) {
+					n++
+				}
+			default:
+				return n
+			}
+		}
+	}
+
+	// The fallback tag awaits approval, which is the reason reported while
+	// the removal is notified once.
+	for i := range 2 {
+		_, err := r.reconcile(ctx, nil, obj)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(obj.Status.LatestRef.Tag).To(Equal("v1.2.0"))
+		g.Expect(obj.Status.PendingRef.Tag).To(Equal("v1.1.0"))
+		g.Expect(conditions.GetReason(obj, meta.ReadyCondition)).To(Equal(imagev1.AwaitingApprovalReason))
+		g.Expect(conditions.GetMessage(obj, meta.ReadyCondition)).To(ContainSubstring("latest tag v1.2.0 is not listed"))
+		g.Expect(obj.Status.RemovedLatestTag).To(Equal("v1.2.0"))
+		g.Expect(removedEvents()).To(Equal(1 - i))
+	}
+
+	// The removal is notified again if the tag is removed after being
+	// listed again.
+	r.Database = &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0", "v1.2.0"}}
+	_, err := r.reconcile(ctx, nil, obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.Status.RemovedLatestTag).To(BeEmpty())
+	g.Expect(removedEvents()).To(Equal(0))
+
+	r.Database = &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}}
+	_, err = r.reconcile(ctx, nil, obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.Status.RemovedLatestTag).To(Equal("v1.2.0"))
+	g.Expect(removedEvents()).To(Equal(1))
+}
+
+func TestImagePolicyReconciler_reconcile_pin(t *testing.T) {
+	tests := []struct {
+		name         string
+		pin          string
+		semverRange  string
+		wantUnpinned *imagev1.ImageRef
+		wantMessage  string
+	}{
+		{
+			name:         "newer tag elected by the policy",
+			pin:          "v1.0.0",
+			semverRange:  ">=1.0.0",
+			wantUnpinned: &imagev1.ImageRef{Name: "ghcr.io/foo/bar", Tag: "v1.1.0"},
+			wantMessage:  "Latest image tag for ghcr.io/foo/bar resolved to v1.0.0, pinned, newer available: v1.1.0",
+		},
+		{
+			name:         "older tag elected by the policy",
+			pin:          "v1.1.0",
+			semverRange:  "<1.1.0",
+			wantUnpinned: &imagev1.ImageRef{Name: "ghcr.io/foo/bar", Tag: "v1.0.0"},
+			wantMessage:  "Latest image tag for ghcr.io/foo/bar resolved to v1.1.0, pinned",
+		},
+		{
+			name:        "no tag elected by the policy",
+			pin:         "v1.0.0",
+			semverRange: ">=2.0.0",
+			wantMessage: "Latest image tag for ghcr.io/foo/bar resolved to v1.0.0, pinned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := runtime.NewScheme()
+			utilruntime.Must(imagev1.AddToScheme(s))
+
+			repo := &imagev1.ImageRepository{}
+			repo.Name = "repo"
+			repo.Namespace = "default"
+			repo.Spec.Image = "ghcr.io/foo/bar"
+
+			r := &ImagePolicyReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(s).WithObjects(repo).Build(),
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}},
+			}
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Name = "test"
+			obj.Namespace = "default"
+			obj.Spec.ImageRepositoryRef = meta.NamespacedObjectReference{Name: repo.Name}
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: tt.semverRange}}
+			obj.Spec.Pin = tt.pin
+
+			_, err := r.reconcile(ctx, nil, obj)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: repo.Spec.Image, Tag: tt.pin}))
+			g.Expect(obj.Status.UnpinnedRef).To(Equal(tt.wantUnpinned))
+			g.Expect(conditions.IsReady(obj)).To(BeTrue())
+			g.Expect(conditions.GetReason(obj, meta.ReadyCondition)).To(Equal(imagev1.PinnedReason))
+			g.Expect(conditions.GetMessage(obj, meta.ReadyCondition)).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_updateImageRefs_approval(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotation  string
+		current     *imagev1.ImageRef
+		wantLatest  *imagev1.ImageRef
+		wantPending *imagev1.ImageRef
+	}{
+		{
+			name:        "no latest tag yet",
+			wantPending: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+		{
+			name:        "not approved",
+			current:     &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest:  &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantPending: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+		{
+			name:        "another tag approved",
+			annotation:  "v1.2.0",
+			current:     &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest:  &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantPending: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+		{
+			name:        "tag approved with digest",
+			annotation:  "v1.1.0@sha256:1234567890abcdef",
+			current:     &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest:  &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantPending: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+		{
+			name:       "approved",
+			annotation: "v1.1.0",
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+		{
+			name:       "already latest",
+			current:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+			wantLatest: &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ImagePolicyReconciler{}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = "foo/bar"
+
+			obj := &imagev1.ImagePolicy{}
+			if tt.annotation != "" {
+				obj.SetAnnotations(map[string]string{imagev1.ApprovedRefAnnotation: tt.annotation})
+			}
+			obj.Spec.RequireApproval = true
+			obj.Status.LatestRef = tt.current
+			obj.Status.PendingRef = &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.5"}
+
+			g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, time.Time{})).To(Succeed())
+			g.Expect(obj.Status.LatestRef).To(Equal(tt.wantLatest))
+			g.Expect(obj.Status.PendingRef).To(Equal(tt.wantPending))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_updateImageRefs_promotionWindow(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ImagePolicyReconciler{}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = "foo/bar"
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Status.LatestRef = &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"}
+
+	nextWindow := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, nextWindow)).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"}))
+	g.Expect(obj.Status.PendingRef).To(Equal(&imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"}))
+	g.Expect(obj.Status.NextPromotionTime).To(Equal(&metav1.Time{Time: nextWindow}))
+
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"}))
+	g.Expect(obj.Status.PendingRef).To(BeNil())
+	g.Expect(obj.Status.NextPromotionTime).To(BeNil())
+}
+
+func TestImagePolicyReconciler_updateImageRefs_pin(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ImagePolicyReconciler{}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = "foo/bar"
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Spec.DigestReflectionPolicy = imagev1.ReflectAlways
+	obj.Spec.RequireApproval = true
+	obj.Spec.Pin = "v1.0.0@sha256:1234567890abcdef"
+	obj.Status.LatestRef = &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"}
+
+	nextWindow := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.0.0", nil, nextWindow)).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0", Digest: "sha256:1234567890abcdef"}))
+	g.Expect(obj.Status.PendingRef).To(BeNil())
+	g.Expect(obj.Status.NextPromotionTime).To(BeNil())
+}
+
+func TestImagePolicyReconciler_updateImageRefs_resolveVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo, digests, err := test.LoadImages(registryServer, "foo/bar", []string{"3.4.0", "3.4.1", "3.5.0-rc.1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	stable, err := name.NewTag(imgRepo + ":stable")
+	g.Expect(err).ToNot(HaveOccurred())
+	desc, err := remote.Get(stable.Context().Tag("3.4.1"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.Tag(stable, desc)).To(Succeed())
+
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = imgRepo
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.DigestReflectionPolicy = imagev1.ReflectAlways
+
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "stable", []string{"3.5.0-rc.1", "3.4.1", "3.4.0"}, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "3.4.1", Digest: digests["3.4.1"].String()}))
+
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "stable", []string{"3.4.0"}, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "stable", Digest: digests["3.4.1"].String()}))
+}
+
+func TestImagePolicyReconciler_updateImageRefs_platforms(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo, digests, err := test.LoadImages(registryServer, "foo/bar", []string{"v1.0.0"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	idx, err := random.Index(0, 0, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	var platforms []imagev1.PlatformDigest
+	for _, platform := range []*v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	} {
+		img, err := random.Image(512, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: platform},
+		})
+		digest, err := img.Digest()
+		g.Expect(err).ToNot(HaveOccurred())
+		platforms = append(platforms, imagev1.PlatformDigest{Digest: digest.String()})
+	}
+	platforms[0].Platform = "linux/amd64"
+	platforms[1].Platform = "linux/arm64/v8"
+	idxRef, err := name.NewTag(imgRepo + ":v1.1.0")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.WriteIndex(idxRef, idx)).To(Succeed())
+	idxDigest, err := idx.Digest()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = imgRepo
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.DigestReflectionPolicy = imagev1.ReflectIfNotPresent
+	obj.Spec.Platforms = &imagev1.PlatformsSpec{}
+
+	// The platform digests of an image index are reflected along with its
+	// digest.
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "v1.1.0", Digest: idxDigest.String()}))
+	g.Expect(obj.Status.PlatformDigests).To(Equal(platforms))
+	g.Expect(obj.Status.ManifestDigest).To(Equal(idxDigest.String()))
+
+	// Selecting a platform fetches the digest again. The default variant of
+	// the architecture can be omitted.
+	obj.Spec.Platforms.Select = "linux/arm64"
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "v1.1.0", Digest: platforms[1].Digest}))
+	g.Expect(obj.Status.PlatformDigests).To(Equal(platforms))
+	g.Expect(platformsReflected(obj)).To(BeTrue())
+
+	// Missing platforms are an error.
+	obj.Spec.Platforms.Select = "linux/s390x"
+	err = r.updateImageRefs(context.Background(), repo, obj, "v1.1.0", nil, time.Time{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("no manifest for platform linux/s390x"))
+
+	// Images which are not an index are reflected with their digest.
+	obj.Spec.Platforms.Select = "linux/arm64"
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.0.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "v1.0.0", Digest: digests["v1.0.0"].String()}))
+	g.Expect(obj.Status.PlatformDigests).To(BeNil())
+	g.Expect(obj.Status.ManifestDigest).To(Equal(digests["v1.0.0"].String()))
+
+	// The reflected image is not fetched again.
+	registryServer.Close()
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.0.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.LatestRef).To(Equal(&imagev1.ImageRef{Name: imgRepo, Tag: "v1.0.0", Digest: digests["v1.0.0"].String()}))
+
+	obj.Spec.Platforms = nil
+	obj.Status.PlatformDigests = platforms
+	g.Expect(r.updateImageRefs(context.Background(), repo, obj, "v1.0.0", nil, time.Time{})).To(Succeed())
+	g.Expect(obj.Status.PlatformDigests).To(BeNil())
+	g.Expect(obj.Status.ManifestDigest).To(BeEmpty())
+}
+
+func TestResolvableVersions(t *testing.T) {
+	tags := []string{"stable", "latest", "3.4.0", "3.4.1", "3.5.0-rc.1", "4.0.0", "3.9.0"}
+
+	tests := []struct {
+		name       string
+		resolution imagev1.VersionResolution
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "defaults",
+			want: []string{"4.0.0", "3.4.1", "3.4.0"},
+		},
+		{
+			name:       "range",
+			resolution: imagev1.VersionResolution{Range: "3.x"},
+			want:       []string{"3.4.1", "3.4.0"},
+		},
+		{
+			name:       "max tags",
+			resolution: imagev1.VersionResolution{MaxTags: 2},
+			want:       []string{"4.0.0", "3.4.1"},
+		},
+		{
+			name:       "no version in range",
+			resolution: imagev1.VersionResolution{Range: "5.x"},
+		},
+		{
+			name:       "invalid range",
+			resolution: imagev1.VersionResolution{Range: "not a range"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = "foo/bar"
+			mirror := &imagev1.ImageRepository{}
+			mirror.Spec.Image = "mirror/foo/bar"
+			sources := map[string]*imagev1.ImageRepository{"3.9.0": mirror}
+
+			versions, err := resolvableVersions(tt.resolution, repo, sources, "stable", tags)
+			if tt.wantErr {
+				g.Expect(err).To(BeAssignableToTypeOf(errInvalidPolicy{}))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(versions).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestRecordDigest(t *testing.T) {
+	g := NewWithT(t)
+
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ref := func(digest string) imagev1.ImageRef {
+		return imagev1.ImageRef{Name: "foo/bar", Tag: "stable", Digest: digest}
+	}
+
+	obj := &imagev1.ImagePolicy{}
+	recordDigest(obj, ref("sha256:1"), base)
+	recordDigest(obj, ref("sha256:1"), base.Add(time.Hour))
+	g.Expect(obj.Status.DigestHistory).To(Equal([]imagev1.ObservedImageRef{
+		{ImageRef: ref("sha256:1"), ObservedAt: metav1.NewTime(base)},
+	}))
+
+	recordDigest(obj, ref("sha256:2"), base.Add(2*time.Hour))
+	g.Expect(obj.Status.DigestHistory).To(Equal([]imagev1.ObservedImageRef{
+		{ImageRef: ref("sha256:2"), ObservedAt: metav1.NewTime(base.Add(2 * time.Hour))},
+		{ImageRef: ref("sha256:1"), ObservedAt: metav1.NewTime(base)},
+	}))
+
+	for i := range 2 * maxDigestHistory {
+		recordDigest(obj, ref(fmt.Sprintf("sha256:%d", i+3)), base.Add(time.Duration(i+3)*time.Hour))
+	}
+	g.Expect(obj.Status.DigestHistory).To(HaveLen(maxDigestHistory))
+	g.Expect(obj.Status.DigestHistory[0].Digest).To(Equal(fmt.Sprintf("sha256:%d", 2*maxDigestHistory+2)))
+}
+
+func TestIsApproved(t *testing.T) {
+	tests := []struct {
+		annotation string
+		digest     string
+		want       bool
+	}{
+		{annotation: "", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "v1.1.0", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "sha256:1234567890abcdef", digest: "sha256:1234567890abcdef", want: true},
+		{annotation: "v1.1.0@sha256:1234567890abcdef", digest: "sha256:1234567890abcdef", want: true},
+		{annotation: "v1.1.0@sha256:abcdef1234567890", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "v1.0.0@sha256:1234567890abcdef", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "v1.1.0@", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "v1.0.0", digest: "sha256:1234567890abcdef", want: false},
+		{annotation: "", want: false},
+		{annotation: "v1.1.0", want: true},
+		{annotation: "v1.0.0", want: false},
+		{annotation: "v1.1.0@sha256:1234567890abcdef", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.annotation+"/"+tt.digest, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ref := &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0", Digest: tt.digest}
+			obj := &imagev1.ImagePolicy{}
+			obj.SetAnnotations(map[string]string{imagev1.ApprovedRefAnnotation: tt.annotation})
+			g.Expect(isApproved(obj, ref)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_pin(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ImagePolicyReconciler{
+		EventRecorder: record.NewFakeRecorder(32),
+		Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0"}},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = "foo/bar"
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+	obj.Spec.Pin = "v1.0.0@sha256:1234567890abcdef"
+
+	res, err := r.applyPolicy(ctx, obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.0.0"))
+	g.Expect(res.unpinned).To(Equal("v1.1.0"))
+	g.Expect(res.newer).To(BeTrue())
+
+	// The tag is pinned even if the policy elects none.
+	obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=2.0.0"}}
+	res, err = r.applyPolicy(ctx, obj, repo)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.latest).To(Equal("v1.0.0"))
+	g.Expect(res.unpinned).To(BeEmpty())
+
+	obj.Spec.Pin = "v0.9.0"
+	_, err = r.applyPolicy(ctx, obj, repo)
+	g.Expect(err).To(BeAssignableToTypeOf(errInvalidPolicy{}))
+	g.Expect(err.Error()).To(ContainSubstring("pinned tag v0.9.0 is not listed"))
+}
+
+func TestImagePolicyReconciler_applyPolicy_bounds(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       *imagev1.TagFilter
+		ceiling      *imagev1.ImageRef
+		floor        *imagev1.ImageRef
+		noCrossNS    bool
+		wantLatest   string
+		wantErr      string
+		wantNotReady bool
+	}{
+		{
+			name:       "ceiling",
+			ceiling:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "ceiling between tags",
+			ceiling:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.5"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "ceiling with extracted values",
+			filter:     &imagev1.TagFilter{Pattern: `^v(?P<version>.*)$`, Extract: "$version"},
+			ceiling:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.0.0"},
+			wantLatest: "v1.0.0",
+		},
+		{
+			name:       "ceiling outside of the policy range",
+			ceiling:    &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.2.0-rc.1"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:    "ceiling which cannot be ordered",
+			ceiling: &imagev1.ImageRef{Name: "foo/bar", Tag: "latest"},
+			wantErr: "latest tag latest of ImagePolicy staging/ceiling cannot be ordered by the policy",
+		},
+		{
+			name:       "floor",
+			floor:      &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+			wantLatest: "v1.2.0",
+		},
+		{
+			name:       "floor outside of the policy range",
+			floor:      &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0-rc.1"},
+			wantLatest: "v1.2.0",
+		},
+		{
+			name:    "floor above all tags",
+			floor:   &imagev1.ImageRef{Name: "foo/bar", Tag: "v2.0.0"},
+			wantErr: "no tag ranks at least as high as v2.0.0",
+		},
+		{
+			name:         "ceiling without latest image",
+			ceiling:      &imagev1.ImageRef{},
+			wantNotReady: true,
+		},
+		{
+			name:      "cross-namespace ceiling blocked",
+			ceiling:   &imagev1.ImageRef{Name: "foo/bar", Tag: "v1.1.0"},
+			noCrossNS: true,
+			wantErr:   "cross-namespace references have been blocked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := runtime.NewScheme()
+			utilruntime.Must(imagev1.AddToScheme(s))
+
+			var objects []client.Object
+			for name, ref := range map[string]*imagev1.ImageRef{"ceiling": tt.ceiling, "floor": tt.floor} {
+				if ref == nil {
+					continue
+				}
+				bound := &imagev1.ImagePolicy{}
+				bound.Name = name
+				bound.Namespace = "staging"
+				if ref.Tag != "" {
+					bound.Status.LatestRef = ref
+				}
+				objects = append(objects, bound)
+			}
+
+			r := &ImagePolicyReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{TagData: []string{"v1.0.0", "v1.1.0", "v1.2.0"}},
+				ACLOptions:    acl.Options{NoCrossNamespaceRefs: tt.noCrossNS},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Spec.Image = "foo/bar"
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Name = "test"
+			obj.Namespace = "production"
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			obj.Spec.FilterTags = tt.filter
+			if tt.ceiling != nil {
+				obj.Spec.CeilingPolicyRef = &meta.NamespacedObjectReference{Name: "ceiling", Namespace: "staging"}
+			}
+			if tt.floor != nil {
+				obj.Spec.FloorPolicyRef = &meta.NamespacedObjectReference{Name: "floor", Namespace: "staging"}
+			}
+
+			res, err := r.applyPolicy(ctx, obj, repo)
+			switch {
+			case tt.wantNotReady:
+				g.Expect(errors.Is(err, errBoundPolicyNotReady)).To(BeTrue())
+			case tt.wantErr != "":
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+			default:
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res.latest).To(Equal(tt.wantLatest))
+			}
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_lockstep(t *testing.T) {
+	tests := []struct {
+		name       string
+		lockstep   []string
+		wantLatest string
+		wantErr    string
+	}{
+		{
+			name:       "no lockstep repositories",
+			wantLatest: "v1.2.0",
+		},
+		{
+			name:       "one lockstep repository",
+			lockstep:   []string{"backend"},
+			wantLatest: "v1.1.0",
+		},
+		{
+			name:       "several lockstep repositories",
+			lockstep:   []string{"backend", "worker"},
+			wantLatest: "v1.0.0",
+		},
+		{
+			name:     "no common tag",
+			lockstep: []string{"backend", "legacy"},
+			wantErr:  "no tag is listed in all the lockstep image repositories",
+		},
+		{
+			name:     "missing lockstep repository",
+			lockstep: []string{"missing"},
+			wantErr:  "failed to get the lockstep ImageRepository",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := runtime.NewScheme()
+			utilruntime.Must(imagev1.AddToScheme(s))
+
+			var objects []client.Object
+			for _, name := range []string{"backend", "worker", "legacy"} {
+				lockstepRepo := &imagev1.ImageRepository{}
+				lockstepRepo.Name = name
+				lockstepRepo.Namespace = "default"
+				objects = append(objects, lockstepRepo)
+			}
+
+			r := &ImagePolicyReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+				EventRecorder: record.NewFakeRecorder(32),
+				Database: &mockDatabase{RepoTagData: map[string][]string{
+					"frontend": {"v1.0.0", "v1.1.0", "v1.2.0"},
+					"backend":  {"v1.0.0", "v1.1.0"},
+					"worker":   {"v0.9.0", "v1.0.0"},
+					"legacy":   {"v0.9.0"},
+				}},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Name = "frontend"
+			repo.Namespace = "default"
+			repo.Spec.Image = "foo/frontend"
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Name = "frontend"
+			obj.Namespace = "default"
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			for _, name := range tt.lockstep {
+				obj.Spec.LockstepRepositoryRefs = append(obj.Spec.LockstepRepositoryRefs, meta.NamespacedObjectReference{Name: name})
+			}
+
+			res, err := r.applyPolicy(ctx, obj, repo)
+			if tt.wantErr != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_applyPolicy_mirrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        map[string][]string
+		current     *imagev1.ImageRef
+		wantLatest  string
+		wantSource  string
+		wantRemoved string
+		wantErr     bool
+	}{
+		{
+			name: "latest tag in repository",
+			tags: map[string][]string{
+				"primary": {"v1.0.0", "v1.1.0"},
+				"mirror":  {"v1.0.0", "v1.1.0"},
+			},
+			wantLatest: "v1.1.0",
+			wantSource: "primary",
+		},
+		{
+			name: "latest tag only in mirror",
+			tags: map[string][]string{
+				"primary": {"v1.0.0"},
+				"mirror":  {"v1.0.0", "v1.1.0"},
+			},
+			wantLatest: "v1.1.0",
+			wantSource: "mirror",
+		},
+		{
+			name: "no tags in repository",
+			tags: map[string][]string{
+				"primary": {},
+				"mirror":  {"v1.0.0"},
+			},
+			wantLatest: "v1.0.0",
+			wantSource: "mirror",
+		},
+		{
+			name: "current latest tag from mirror removed",
+			tags: map[string][]string{
+				"primary": {"v1.0.0"},
+				"mirror":  {"v1.0.0"},
+			},
+			current:     &imagev1.ImageRef{Name: "mirror.example.com/foo/bar", Tag: "v1.1.0"},
+			wantLatest:  "v1.0.0",
+			wantSource:  "primary",
+			wantRemoved: "v1.1.0",
+		},
+		{
+			name: "no tags in any repository",
+			tags: map[string][]string{
+				"primary": {},
+				"mirror":  {},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := runtime.NewScheme()
+			utilruntime.Must(imagev1.AddToScheme(s))
+
+			mirror := &imagev1.ImageRepository{}
+			mirror.Name = "mirror"
+			mirror.Namespace = "default"
+			mirror.Spec.Image = "mirror.example.com/foo/bar"
+
+			r := &ImagePolicyReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(s).WithObjects(mirror).Build(),
+				EventRecorder: record.NewFakeRecorder(32),
+				Database:      &mockDatabase{RepoTagData: tt.tags},
+			}
+
+			repo := &imagev1.ImageRepository{}
+			repo.Name = "primary"
+			repo.Namespace = "default"
+			repo.Spec.Image = "foo/bar"
+
+			obj := &imagev1.ImagePolicy{}
+			obj.Name = "test"
+			obj.Namespace = "default"
+			obj.Spec.Policy = imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0"}}
+			obj.Spec.MirrorRepositoryRefs = []meta.NamespacedObjectReference{{Name: "mirror"}}
+			obj.Status.LatestRef = tt.current
+
+			res, err := r.applyPolicy(ctx, obj, repo)
+			if tt.wantErr {
+				g.Expect(errors.Is(err, errNoTagsInDatabase)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.latest).To(Equal(tt.wantLatest))
+			g.Expect(tagSource(repo, res.sources, res.latest).Name).To(Equal(tt.wantSource))
+			g.Expect(res.removed).To(Equal(tt.wantRemoved))
+		})
+	}
+}
+
+func TestPolicyResult_pin(t *testing.T) {
+	tests := []struct {
+		name string
+		res  policyResult
+		want policyResult
+	}{
+		{
+			name: "elected tag",
+			res:  policyResult{latest: "v1.1.0", candidates: []string{"v1.1.0", "v1.0.0"}},
+			want: policyResult{latest: "v1.0.0", unpinned: "v1.1.0", newer: true, candidates: []string{"v1.1.0", "v1.0.0"}},
+		},
+		{
+			name: "older elected tag",
+			res:  policyResult{latest: "v0.9.0"},
+			want: policyResult{latest: "v1.0.0", unpinned: "v0.9.0"},
+		},
+		{
+			name: "rejected tag",
+			res:  policyResult{latest: "v1.2.0", rejected: "v1.1.0"},
+			want: policyResult{latest: "v1.0.0", unpinned: "v1.1.0", newer: true},
+		},
+		{
+			name: "soaking tag",
+			res:  policyResult{soaking: "v1.1.0", eligibleAt: time.Now()},
+			want: policyResult{latest: "v1.0.0"},
+		},
+		{
+			name: "removed tag",
+			res:  policyResult{latest: "v1.1.0", removed: "v1.2.0"},
+			want: policyResult{latest: "v1.0.0", unpinned: "v1.1.0", newer: true},
+		},
+		{
+			name: "no elected tag",
+			res:  policyResult{},
+			want: policyResult{latest: "v1.0.0"},
+		},
+	}
+
+	policer, err := policy.NewSemVer(">=0.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.res.pin("v1.0.0", policer)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestImagePolicyReconciler_updateCandidates(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	imgRepo, digests, err := test.LoadImages(registryServer, "foo/bar", []string{"v1.0.0", "v1.1.0"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &ImagePolicyReconciler{
+		EventRecorder:     record.NewFakeRecorder(32),
+		AuthOptionsGetter: &registry.AuthOptionsGetter{Client: fake.NewClientBuilder().Build()},
+	}
+
+	repo := &imagev1.ImageRepository{}
+	repo.Spec.Image = imgRepo
+
+	obj := &imagev1.ImagePolicy{}
+	obj.Name = "test"
+	obj.Namespace = "default"
+	obj.Spec.Candidates = &imagev1.CandidatesSpec{Count: 2}
+
+	g.Expect(r.updateCandidates(context.Background(), repo, obj, []string{"v1.1.0", "v1.0.0"}, nil)).To(Succeed())
+	g.Expect(obj.Status.Candidates).To(Equal([]imagev1.ImageRef{
+		{Name: imgRepo, Tag: "v1.1.0"},
+		{Name: imgRepo, Tag: "v1.0.0"},
+	}))
+
+	obj.Spec.Candidates.ReflectDigests = true
+	g.Expect(r.updateCandidates(context.Background(), repo, obj, []string{"v1.1.0", "v1.0.0"}, nil)).To(Succeed())
+	g.Expect(obj.Status.Candidates).To(Equal([]imagev1.ImageRef{
+		{Name: imgRepo, Tag: "v1.1.0", Digest: digests["v1.1.0"].String()},
+		{Name: imgRepo, Tag: "v1.0.0", Digest: digests["v1.0.0"].String()},
+	}))
+
+	// The digests already reflected are reused, unless they are to be
+	// fetched on every reconciliation.
+	obj.Status.Candidates[1].Digest = "sha256:1234567890abcdef"
+	g.Expect(r.updateCandidates(context.Background(), repo, obj, []string{"v1.1.0", "v1.0.0"}, nil)).To(Succeed())
+	g.Expect(obj.Status.Candidates[1].Digest).To(Equal("sha256:1234567890abcdef"))
+
+	obj.Spec.DigestReflectionPolicy = imagev1.ReflectAlways
+	g.Expect(r.updateCandidates(context.Background(), repo, obj, []string{"v1.1.0", "v1.0.0"}, nil)).To(Succeed())
+	g.Expect(obj.Status.Candidates[1].Digest).To(Equal(digests["v1.0.0"].String()))
+
+	obj.Spec.Candidates = nil
+	g.Expect(r.updateCandidates(context.Background(), repo, obj, nil, nil)).To(Succeed())
+	g.Expect(obj.Status.Candidates).To(BeNil())
+}
+
+func TestComposeImagePolicyReadyMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         *imagev1.ImagePolicy
+		wantMessage string
+	}{
+		{
+			name: "no previous tag",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0",
+		},
+		{
+			name: "different previous tag",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.1.0",
+					},
+					ObservedPreviousRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.1.0 (previously foo/bar:1.0.0)",
+		},
+		{
+			name: "same previous and latest tags",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					ObservedPreviousRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0",
+		},
+		{
+			name: "different image with digest",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name:   "foo/bar",
+						Tag:    "1.0.0",
+						Digest: "sha256:1234567890abcdef",
+					},
+					ObservedPreviousRef: &imagev1.ImageRef{
+						Name: "baz/qux",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0 with digest sha256:1234567890abcdef (previously baz/qux:1.0.0)",
+		},
+		{
+			name: "different digest",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name:   "foo/bar",
+						Tag:    "1.0.0",
+						Digest: "sha256:1234567890abcdef",
+					},
+					ObservedPreviousRef: &imagev1.ImageRef{
+						Name:   "foo/bar",
+						Tag:    "1.0.0",
+						Digest: "sha256:abcdef1234567890",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0 with digest sha256:1234567890abcdef (previously foo/bar:1.0.0@sha256:abcdef1234567890)",
+		},
+		{
+			name: "soaking tag",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					SoakingRef: &imagev1.SoakingImageRef{
+						ImageRef: imagev1.ImageRef{
+							Name: "foo/bar",
+							Tag:  "1.1.0",
+						},
+						EligibleAt: metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0, 1.1.0 is waiting for the minimum age until 2024-01-02T03:04:05Z",
+		},
+		{
+			name: "pending tag",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					PendingRef: &imagev1.ImageRef{
+						Name:   "foo/bar",
+						Tag:    "1.1.0",
+						Digest: "sha256:1234567890abcdef",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0, foo/bar:1.1.0@sha256:1234567890abcdef is awaiting approval",
+		},
+		{
+			name: "pending tag without latest tag",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					PendingRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "No image tag for foo/bar approved yet, foo/bar:1.0.0 is awaiting approval",
+		},
+		{
+			name: "pinned tag",
+			obj: &imagev1.ImagePolicy{
+				Spec: imagev1.ImagePolicySpec{
+					Pin: "1.0.0",
+				},
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					UnpinnedRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.1.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0, pinned",
+		},
+		{
+			name: "pinned tag elected by the policy",
+			obj: &imagev1.ImagePolicy{
+				Spec: imagev1.ImagePolicySpec{
+					Pin: "1.0.0",
+				},
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					UnpinnedRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0, pinned",
+		},
+		{
+			name: "pending tag outside of promotion windows",
+			obj: &imagev1.ImagePolicy{
+				Status: imagev1.ImagePolicyStatus{
+					LatestRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.0.0",
+					},
+					PendingRef: &imagev1.ImageRef{
+						Name: "foo/bar",
+						Tag:  "1.1.0",
+					},
+					NextPromotionTime: &metav1.Time{Time: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+				},
+			},
+			wantMessage: "Latest image tag for foo/bar resolved to 1.0.0, foo/bar:1.1.0 is awaiting the promotion window opening at 2024-01-02T09:00:00Z",
 		},
 	}
 
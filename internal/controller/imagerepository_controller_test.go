@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"hash/adler32"
+	"maps"
 	"net/http"
 	"net/url"
 	"strings"
@@ -53,9 +54,13 @@ import (
 
 // mockDatabase mocks the image repository database.
 type mockDatabase struct {
-	TagData    []string
-	ReadError  error
-	WriteError error
+	TagData       []string
+	RepoTagData   map[string][]string
+	FirstSeenData map[string]time.Time
+	ReadError     error
+	WriteError    error
+	MetadataData  map[string]storage.ImageMetadata
+	DigestData    map[string]string
 }
 
 // SetTags implements the DatabaseWriter interface of the Database.
@@ -64,6 +69,7 @@ func (db *mockDatabase) SetTags(ctx context.Context, repo storage.RepoIdentity,
 		return "", db.WriteError
 	}
 	db.TagData = append(db.TagData, tags...)
+	db.DigestData = nil
 	return fmt.Sprintf("%v", adler32.Checksum([]byte(strings.Join(tags, ",")))), nil
 }
 
@@ -72,14 +78,44 @@ func (db mockDatabase) Tags(ctx context.Context, repo storage.RepoIdentity) ([]s
 	if db.ReadError != nil {
 		return nil, db.ReadError
 	}
+	if tags, ok := db.RepoTagData[repo.Name]; ok {
+		return tags, nil
+	}
 	return db.TagData, nil
 }
 
+// FirstSeen implements the DatabaseReader interface of the Database.
+func (db mockDatabase) FirstSeen(ctx context.Context, repo storage.RepoIdentity) (map[string]time.Time, error) {
+	if db.ReadError != nil {
+		return nil, db.ReadError
+	}
+	return db.FirstSeenData, nil
+}
+
 // Delete implements the DatabaseWriter interface of the Database.
 func (db *mockDatabase) Delete(ctx context.Context, repo storage.RepoIdentity) error {
 	return nil
 }
 
+// Metadata implements the MetadataCache interface of the Database.
+func (db mockDatabase) Metadata(ctx context.Context, repo storage.RepoIdentity) (storage.RepoMetadata, error) {
+	if db.ReadError != nil {
+		return storage.RepoMetadata{}, db.ReadError
+	}
+	return storage.RepoMetadata{TagDigests: maps.Clone(db.DigestData), Images: maps.Clone(db.MetadataData)}, nil
+}
+
+// SetMetadata implements the MetadataCache interface of the Database.
+func (db *mockDatabase) SetMetadata(ctx context.Context, repo storage.RepoIdentity, metadata storage.RepoMetadata) error {
+	if db.WriteError != nil {
+		return db.WriteError
+	}
+	all := storage.RepoMetadata{TagDigests: db.DigestData, Images: db.MetadataData}
+	all.Merge(metadata)
+	db.DigestData, db.MetadataData = all.TagDigests, all.Images
+	return nil
+}
+
 func TestImageRepositoryReconciler_deleteBeforeFinalizer(t *testing.T) {
 	g := NewWithT(t)
 
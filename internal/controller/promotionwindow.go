@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	imagev1 "github.com/fluxcd/image-reflector-controller/api/v1"
+)
+
+// nextPromotionWindow returns the time the next of the given promotion
+// windows opens at, or the zero time if there are none or one of them is
+// open at the given time.
+func nextPromotionWindow(windows []imagev1.PromotionWindow, now time.Time) (time.Time, error) {
+	var next time.Time
+	for i, w := range windows {
+		loc, err := time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid promotion window %d: %w", i, err)
+		}
+		startHour, startMin, err := parseTimeOfDay(w.Start)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid promotion window %d: %w", i, err)
+		}
+		endHour, endMin, err := parseTimeOfDay(w.End)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid promotion window %d: %w", i, err)
+		}
+		// Windows ending at or before their start close on the next day.
+		endDay := 0
+		if endHour*60+endMin <= startHour*60+startMin {
+			endDay = 1
+		}
+
+		// Look for the window opened on the previous day, which may still
+		// be open, up to the one opening a week later.
+		local := now.In(loc)
+		year, month, day := local.Date()
+		for d := -1; d <= 7; d++ {
+			start := time.Date(year, month, day+d, startHour, startMin, 0, 0, loc)
+			if len(w.Days) > 0 && !slices.Contains(w.Days, imagev1.Weekday(start.Weekday().String())) {
+				continue
+			}
+			end := time.Date(year, month, day+d+endDay, endHour, endMin, 0, 0, loc)
+			if !now.Before(start) && now.Before(end) {
+				return time.Time{}, nil
+			}
+			if start.After(now) {
+				if next.IsZero() || start.Before(next) {
+					next = start
+				}
+				break
+			}
+		}
+	}
+	return next, nil
+}
+
+// parseTimeOfDay parses a time of day in the HH:MM format, up to 24:00.
+func parseTimeOfDay(s string) (int, int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &hour, &minute); err != nil || len(s) != 5 ||
+		hour > 24 || minute > 59 || (hour == 24 && minute > 0) {
+		return 0, 0, fmt.Errorf("invalid time of day '%s', expected HH:MM", s)
+	}
+	return hour, minute, nil
+}
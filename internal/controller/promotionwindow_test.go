@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	imagev1 "github.com/fluxcd/image-reflector-controller/api/v1"
+)
+
+func TestNextPromotionWindow(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	businessHours := imagev1.PromotionWindow{
+		Days:  []imagev1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		Start: "09:00",
+		End:   "17:00",
+	}
+
+	tests := []struct {
+		name     string
+		windows  []imagev1.PromotionWindow
+		now      time.Time
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{
+			name: "no windows",
+			now:  time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "inside window",
+			windows: []imagev1.PromotionWindow{businessHours},
+			now:     time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before window",
+			windows:  []imagev1.PromotionWindow{businessHours},
+			now:      time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "at window end",
+			windows:  []imagev1.PromotionWindow{businessHours},
+			now:      time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "during weekend",
+			windows:  []imagev1.PromotionWindow{businessHours},
+			now:      time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "overnight window opened the day before",
+			windows: []imagev1.PromotionWindow{
+				{Days: []imagev1.Weekday{"Sunday"}, Start: "22:00", End: "02:00"},
+			},
+			now: time.Date(2026, 10, 12, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "window ending at midnight",
+			windows: []imagev1.PromotionWindow{
+				{Start: "20:00", End: "24:00"},
+			},
+			now: time.Date(2026, 10, 12, 23, 59, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of several windows",
+			windows: []imagev1.PromotionWindow{
+				businessHours,
+				{Days: []imagev1.Weekday{"Saturday"}, Start: "06:00", End: "07:00"},
+			},
+			now:      time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			windows: []imagev1.PromotionWindow{
+				{Start: "09:00", End: "17:00", TimeZone: "Europe/Paris"},
+			},
+			now:      time.Date(2026, 10, 12, 6, 30, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 12, 9, 0, 0, 0, paris),
+		},
+		{
+			name: "daylight saving time change",
+			windows: []imagev1.PromotionWindow{
+				{Days: []imagev1.Weekday{"Sunday"}, Start: "09:00", End: "17:00", TimeZone: "Europe/Paris"},
+			},
+			now:      time.Date(2026, 3, 28, 9, 30, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 3, 29, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "invalid time zone",
+			windows: []imagev1.PromotionWindow{
+				{Start: "09:00", End: "17:00", TimeZone: "Mars/Olympus"},
+			},
+			now:     time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name: "invalid time of day",
+			windows: []imagev1.PromotionWindow{
+				{Start: "9:00", End: "17:00"},
+			},
+			now:     time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			next, err := nextPromotionWindow(tt.windows, tt.now)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(next.Equal(tt.wantNext)).To(BeTrue(), "got %s, want %s", next, tt.wantNext)
+		})
+	}
+}
@@ -20,25 +20,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/adler32"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 
 	"github.com/fluxcd/image-reflector-controller/internal/storage"
 )
 
-const tagsPrefix = "tags"
+const (
+	tagsPrefix     = "tags"
+	metadataPrefix = "metadata"
+)
 
 // BadgerDatabase provides implementations of the tags database based on Badger.
 type BadgerDatabase struct {
-	db *badger.DB
+	db      *badger.DB
+	history *storage.TagHistory
 }
 
 // NewBadgerDatabase creates and returns a new database implementation using
-// Badger for storing the image tags.
+// Badger for storing the image tags. The tag history is stored next to the
+// Badger files, unless the database is held in memory.
 func NewBadgerDatabase(db *badger.DB) *BadgerDatabase {
-	return &BadgerDatabase{
+	a := &BadgerDatabase{
 		db: db,
 	}
+	if opts := db.Opts(); !opts.InMemory && opts.Dir != "" {
+		a.history = storage.NewTagHistory(opts.Dir)
+	}
+	return a
 }
 
 // Tags implements the DatabaseReader interface, fetching the tags for the repo.
@@ -63,7 +73,8 @@ func (a *BadgerDatabase) Tags(ctx context.Context, repo storage.RepoIdentity) ([
 // SetTags implements the DatabaseWriter interface, recording the tags against
 // the repo.
 //
-// It overwrites existing tag sets for the provided repo.
+// It overwrites existing tag sets for the provided repo, and resets the image
+// metadata of the repo.
 func (a *BadgerDatabase) SetTags(ctx context.Context, repo storage.RepoIdentity, tags []string) (string, error) {
 	select {
 	case <-ctx.Done():
@@ -77,26 +88,124 @@ func (a *BadgerDatabase) SetTags(ctx context.Context, repo storage.RepoIdentity,
 	}
 	err = a.db.Update(func(txn *badger.Txn) error {
 		e := badger.NewEntry(keyForRepo(tagsPrefix, repo.CanonicalName), b)
-		return txn.SetEntry(e)
+		if err := txn.SetEntry(e); err != nil {
+			return err
+		}
+		metadata, err := getMetadata(txn, repo.CanonicalName)
+		if err != nil || !metadata.Reset() {
+			return err
+		}
+		return setMetadata(txn, repo.CanonicalName, metadata)
 	})
 	if err != nil {
 		return "", err
 	}
+	if a.hasHistory(repo) {
+		if err := a.history.Record(repo, tags, time.Now()); err != nil {
+			return "", err
+		}
+	}
 	return fmt.Sprintf("%v", adler32.Checksum(b)), nil
 }
 
+// FirstSeen implements the DatabaseReader interface, fetching the time each
+// tag of the repo was first observed.
+//
+// Unlike the tags, the history is kept per ImageRepository object. If no
+// history is recorded for the repo, an empty map is returned.
+func (a *BadgerDatabase) FirstSeen(ctx context.Context, repo storage.RepoIdentity) (map[string]time.Time, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if !a.hasHistory(repo) {
+		return map[string]time.Time{}, nil
+	}
+	return a.history.FirstSeen(repo)
+}
+
+// hasHistory returns true if the tag history can be recorded for the repo.
+func (a *BadgerDatabase) hasHistory(repo storage.RepoIdentity) bool {
+	return a.history != nil && repo.Namespace != "" && repo.Name != ""
+}
+
 // Delete implements the DatabaseWriter interface. Badger keys tags by canonical
-// image name, which may be shared by multiple ImageRepository objects, so delete
-// is intentionally a no-op to preserve existing behavior.
+// image name, which may be shared by multiple ImageRepository objects, so only
+// the tag history of the repo is deleted to preserve existing behavior.
 func (a *BadgerDatabase) Delete(ctx context.Context, repo storage.RepoIdentity) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	default:
 	}
+	if a.hasHistory(repo) {
+		return a.history.Delete(repo)
+	}
 	return nil
 }
 
+// Metadata implements the MetadataCache interface, fetching the image
+// metadata recorded for the repo.
+func (a *BadgerDatabase) Metadata(ctx context.Context, repo storage.RepoIdentity) (storage.RepoMetadata, error) {
+	select {
+	case <-ctx.Done():
+		return storage.RepoMetadata{}, ctx.Err()
+	default:
+	}
+
+	var metadata storage.RepoMetadata
+	err := a.db.View(func(txn *badger.Txn) error {
+		var err error
+		metadata, err = getMetadata(txn, repo.CanonicalName)
+		return err
+	})
+	return metadata, err
+}
+
+// SetMetadata implements the MetadataCache interface, merging the given image
+// metadata into the one recorded for the repo.
+func (a *BadgerDatabase) SetMetadata(ctx context.Context, repo storage.RepoIdentity, metadata storage.RepoMetadata) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	return a.db.Update(func(txn *badger.Txn) error {
+		all, err := getMetadata(txn, repo.CanonicalName)
+		if err != nil {
+			return err
+		}
+		all.Merge(metadata)
+		return setMetadata(txn, repo.CanonicalName, all)
+	})
+}
+
+func getMetadata(txn *badger.Txn, repo string) (storage.RepoMetadata, error) {
+	var metadata storage.RepoMetadata
+	item, err := txn.Get(keyForRepo(metadataPrefix, repo))
+	if err == badger.ErrKeyNotFound {
+		return metadata, nil
+	}
+	if err != nil {
+		return metadata, err
+	}
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &metadata)
+	})
+	return metadata, err
+}
+
+func setMetadata(txn *badger.Txn, repo string, metadata storage.RepoMetadata) error {
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return txn.SetEntry(badger.NewEntry(keyForRepo(metadataPrefix, repo), b))
+}
+
 func keyForRepo(prefix, repo string) []byte {
 	return []byte(fmt.Sprintf("%s:%s", prefix, repo))
 }
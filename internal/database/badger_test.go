@@ -21,6 +21,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 
@@ -83,6 +84,108 @@ func TestGetOnlyFetchesForRepo(t *testing.T) {
 	}
 }
 
+func TestFirstSeen(t *testing.T) {
+	db := createBadgerDatabase(t)
+	repo := tagstorage.RepoIdentity{Namespace: "default", Name: "testing", CanonicalName: testRepo}
+
+	before := time.Now()
+	_, err := db.SetTags(context.Background(), repo, []string{"v0.0.1"})
+	fatalIfError(t, err)
+	firstSeen, err := db.FirstSeen(context.Background(), repo)
+	fatalIfError(t, err)
+	seen := firstSeen["v0.0.1"]
+	if seen.Before(before.Truncate(time.Second)) || seen.After(time.Now()) {
+		t.Fatalf("FirstSeen() got %s, want time of SetTags", seen)
+	}
+
+	_, err = db.SetTags(context.Background(), repo, []string{"v0.0.1", "v0.0.2"})
+	fatalIfError(t, err)
+	firstSeen, err = db.FirstSeen(context.Background(), repo)
+	fatalIfError(t, err)
+	if len(firstSeen) != 2 || !firstSeen["v0.0.1"].Equal(seen) {
+		t.Fatalf("FirstSeen() got %#v, want v0.0.1 first seen at %s", firstSeen, seen)
+	}
+
+	// The history is kept per ImageRepository object.
+	other, err := db.FirstSeen(context.Background(), tagstorage.RepoIdentity{Namespace: "default", Name: "other", CanonicalName: testRepo})
+	fatalIfError(t, err)
+	if len(other) != 0 {
+		t.Fatalf("FirstSeen() for other repo got %#v, want empty", other)
+	}
+
+	fatalIfError(t, db.Delete(context.Background(), repo))
+	firstSeen, err = db.FirstSeen(context.Background(), repo)
+	fatalIfError(t, err)
+	if len(firstSeen) != 0 {
+		t.Fatalf("FirstSeen() after delete got %#v, want empty", firstSeen)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	db := createBadgerDatabase(t)
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	metadata, err := db.Metadata(ctx, repoIdentity(testRepo))
+	fatalIfError(t, err)
+	if !reflect.DeepEqual(metadata, tagstorage.RepoMetadata{}) {
+		t.Fatalf("Metadata() for unknown repo got %#v, want empty", metadata)
+	}
+
+	_, err = db.SetTags(ctx, repoIdentity(testRepo), []string{"latest", "v1"})
+	fatalIfError(t, err)
+	fatalIfError(t, db.SetMetadata(ctx, repoIdentity(testRepo), tagstorage.RepoMetadata{
+		TagDigests: map[string]string{"latest": "sha256:aaa"},
+		Images:     map[string]tagstorage.ImageMetadata{"sha256:aaa": {Created: created}},
+	}))
+	fatalIfError(t, db.SetMetadata(ctx, repoIdentity(testRepo), tagstorage.RepoMetadata{
+		TagDigests: map[string]string{"v1": "sha256:bbb"},
+		Images:     map[string]tagstorage.ImageMetadata{"sha256:bbb": {Created: created.Add(time.Hour)}},
+	}))
+
+	metadata, err = db.Metadata(ctx, repoIdentity(testRepo))
+	fatalIfError(t, err)
+	want := tagstorage.RepoMetadata{
+		TagDigests: map[string]string{"latest": "sha256:aaa", "v1": "sha256:bbb"},
+		Images: map[string]tagstorage.ImageMetadata{
+			"sha256:aaa": {Created: created},
+			"sha256:bbb": {Created: created.Add(time.Hour)},
+		},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() got %#v, want %#v", metadata, want)
+	}
+
+	// Recording the tags forgets the tag digests, but keeps the metadata of
+	// the images they pointed to until the tags are recorded again.
+	_, err = db.SetTags(ctx, repoIdentity(testRepo), []string{"v1", "v2"})
+	fatalIfError(t, err)
+	metadata, err = db.Metadata(ctx, repoIdentity(testRepo))
+	fatalIfError(t, err)
+	want = tagstorage.RepoMetadata{Images: want.Images}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() after SetTags got %#v, want %#v", metadata, want)
+	}
+
+	fatalIfError(t, db.SetMetadata(ctx, repoIdentity(testRepo), tagstorage.RepoMetadata{
+		TagDigests: map[string]string{"v1": "sha256:bbb"},
+	}))
+	_, err = db.SetTags(ctx, repoIdentity(testRepo), []string{"v1", "v2"})
+	fatalIfError(t, err)
+	metadata, err = db.Metadata(ctx, repoIdentity(testRepo))
+	fatalIfError(t, err)
+	want = tagstorage.RepoMetadata{Images: map[string]tagstorage.ImageMetadata{"sha256:bbb": {Created: created.Add(time.Hour)}}}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() after pruning got %#v, want %#v", metadata, want)
+	}
+
+	metadata, err = db.Metadata(ctx, repoIdentity("another/repo"))
+	fatalIfError(t, err)
+	if !reflect.DeepEqual(metadata, tagstorage.RepoMetadata{}) {
+		t.Fatalf("Metadata() for another repo got %#v, want empty", metadata)
+	}
+}
+
 func createBadgerDatabase(t *testing.T) *BadgerDatabase {
 	t.Helper()
 	dir, err := os.MkdirTemp(os.TempDir(), "badger")
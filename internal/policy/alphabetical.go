@@ -17,8 +17,12 @@ limitations under the License.
 package policy
 
 import (
+	"cmp"
 	"fmt"
 	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -31,6 +35,12 @@ const (
 // Alphabetical representes a alphabetical ordering policy
 type Alphabetical struct {
 	Order string
+	// Natural compares the runs of digits embedded in the tags by their
+	// numerical value, so that build-9 is ordered before build-10.
+	Natural bool
+	// CaseInsensitive compares the tags regardless of the case of the
+	// letters.
+	CaseInsensitive bool
 }
 
 // NewAlphabetical constructs a Alphabetical object validating the provided
@@ -56,11 +66,105 @@ func (p *Alphabetical) Latest(versions []string) (string, error) {
 		return "", fmt.Errorf("version list argument cannot be empty")
 	}
 
-	var sorted sort.StringSlice = versions
+	latest := versions[0]
+	for _, v := range versions[1:] {
+		if p.before(latest, v) {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+// Rank returns the provided list of strings ordered from the latest to the
+// oldest
+func (p *Alphabetical) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	ranked := append([]string(nil), versions...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return p.before(ranked[j], ranked[i])
+	})
+	return ranked, nil
+}
+
+// Compare compares two strings according to the configured order
+func (p *Alphabetical) Compare(a, b string) (int, bool) {
+	c := p.compare(a, b)
+	if p.Order == AlphabeticalOrderDesc {
+		c = -c
+	}
+	return c, true
+}
+
+// before reports whether a is ranked before b, i.e. is older than b
+// according to the configured order.
+func (p *Alphabetical) before(a, b string) bool {
+	c := p.compare(a, b)
 	if p.Order == AlphabeticalOrderDesc {
-		sort.Sort(sorted)
-	} else {
-		sort.Sort(sort.Reverse(sorted))
+		return c > 0
+	}
+	return c < 0
+}
+
+// compare compares a and b according to the configured collation. Strings
+// which are equal under the collation are compared byte-wise, to keep the
+// ordering deterministic.
+func (p *Alphabetical) compare(a, b string) int {
+	var c int
+	switch {
+	case p.Natural:
+		c = compareNatural(a, b, p.CaseInsensitive)
+	case p.CaseInsensitive:
+		c = strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareNatural compares a and b character by character, except for the
+// runs of digits found at the same position in both strings, which are
+// compared by their numerical value.
+func compareNatural(a, b string, fold bool) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			da, db := digitRun(a), digitRun(b)
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(na) != len(nb) {
+				return cmp.Compare(len(na), len(nb))
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if fold {
+			ra, rb = unicode.ToLower(ra), unicode.ToLower(rb)
+		}
+		if ra != rb {
+			return cmp.Compare(int(ra), int(rb))
+		}
+		a, b = a[sa:], b[sb:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digitRun returns the leading run of digits of s.
+func digitRun(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
 	}
-	return sorted[0], nil
+	return s[:i]
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package policy
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -62,6 +63,8 @@ func TestAlphabetical_Latest(t *testing.T) {
 	cases := []struct {
 		label           string
 		order           string
+		natural         bool
+		caseInsensitive bool
 		versions        []string
 		expectedVersion string
 		expectErr       bool
@@ -115,6 +118,54 @@ func TestAlphabetical_Latest(t *testing.T) {
 			order:           AlphabeticalOrderDesc,
 			expectedVersion: "1990-01-08T00-20-00Z",
 		},
+		{
+			label:           "With unpadded build numbers",
+			versions:        []string{"build-9", "build-10", "build-2"},
+			expectedVersion: "build-9",
+		},
+		{
+			label:           "With unpadded build numbers natural",
+			natural:         true,
+			versions:        []string{"build-9", "build-10", "build-2"},
+			expectedVersion: "build-10",
+		},
+		{
+			label:           "With unpadded build numbers natural desc",
+			order:           AlphabeticalOrderDesc,
+			natural:         true,
+			versions:        []string{"build-9", "build-10", "build-2"},
+			expectedVersion: "build-2",
+		},
+		{
+			label:           "With several digit runs natural",
+			natural:         true,
+			versions:        []string{"v1.9-rc2", "v1.10-rc1", "v1.10-rc10", "v1.10-rc9"},
+			expectedVersion: "v1.10-rc10",
+		},
+		{
+			label:           "With leading zeros natural",
+			natural:         true,
+			versions:        []string{"r007", "r7", "r06"},
+			expectedVersion: "r7",
+		},
+		{
+			label:           "With mixed case",
+			versions:        []string{"Zeta", "alpha", "beta"},
+			expectedVersion: "beta",
+		},
+		{
+			label:           "With mixed case insensitive",
+			caseInsensitive: true,
+			versions:        []string{"Zeta", "alpha", "beta"},
+			expectedVersion: "Zeta",
+		},
+		{
+			label:           "With mixed case insensitive natural",
+			natural:         true,
+			caseInsensitive: true,
+			versions:        []string{"Build-9", "build-10", "BUILD-2"},
+			expectedVersion: "build-10",
+		},
 		{
 			label:     "Empty version list",
 			versions:  []string{},
@@ -128,6 +179,8 @@ func TestAlphabetical_Latest(t *testing.T) {
 			if err != nil {
 				t.Fatalf("returned unexpected error: %s", err)
 			}
+			policy.Natural = tt.natural
+			policy.CaseInsensitive = tt.caseInsensitive
 			latest, err := policy.Latest(tt.versions)
 			if tt.expectErr && err == nil {
 				t.Fatalf("expecting error, got nil")
@@ -142,3 +195,59 @@ func TestAlphabetical_Latest(t *testing.T) {
 		})
 	}
 }
+
+func TestAlphabetical_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		order          string
+		natural        bool
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label:          "With ascending order",
+			versions:       []string{"bbb", "aaa", "ccc"},
+			order:          AlphabeticalOrderAsc,
+			expectedRanked: []string{"ccc", "bbb", "aaa"},
+		},
+		{
+			label:          "With descending order",
+			versions:       []string{"bbb", "aaa", "ccc"},
+			order:          AlphabeticalOrderDesc,
+			expectedRanked: []string{"aaa", "bbb", "ccc"},
+		},
+		{
+			label:          "With natural collation",
+			versions:       []string{"build-9", "build-10", "build-2", "build-09"},
+			natural:        true,
+			expectedRanked: []string{"build-10", "build-9", "build-09", "build-2"},
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewAlphabetical(tt.order)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			policy.Natural = tt.natural
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
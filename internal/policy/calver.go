@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// calverTokens maps the supported CalVer format tokens to the regular
+// expression matching their values. Longer tokens are listed first so that
+// YYYY is not parsed as two YY tokens.
+var calverTokens = []struct {
+	name    string
+	pattern string
+	year    bool
+}{
+	{name: "YYYY", pattern: `[0-9]{4}`, year: true},
+	{name: "MAJOR", pattern: `0|[1-9][0-9]*`},
+	{name: "MINOR", pattern: `0|[1-9][0-9]*`},
+	{name: "MICRO", pattern: `0|[1-9][0-9]*`},
+	{name: "N", pattern: `[0-9]+`},
+	{name: "YY", pattern: `[0-9]|[1-9][0-9]{1,2}`, year: true},
+	{name: "0Y", pattern: `[0-9]{2,3}`, year: true},
+	{name: "MM", pattern: `1[0-2]|[1-9]`},
+	{name: "0M", pattern: `0[1-9]|1[0-2]`},
+	{name: "WW", pattern: `5[0-3]|[1-4][0-9]|[1-9]`},
+	{name: "0W", pattern: `5[0-3]|[1-4][0-9]|0[1-9]`},
+	{name: "DD", pattern: `3[01]|[12][0-9]|[1-9]`},
+	{name: "0D", pattern: `3[01]|[12][0-9]|0[1-9]`},
+}
+
+// calverConstraintPattern matches a single comparison constraint such as
+// '>=2024.01'.
+var calverConstraintPattern = regexp.MustCompile(`^(>=|<=|!=|>|<|=)?([0-9]+(?:[^0-9\s,]+[0-9]+)*)$`)
+
+// CalVer represents a calendar versioning policy
+type CalVer struct {
+	Format string
+	Range  string
+
+	regexp      *regexp.Regexp
+	years       []bool
+	constraints []calverConstraint
+}
+
+type calverConstraint struct {
+	op      string
+	version []int
+}
+
+// NewCalVer constructs a CalVer object validating the provided format and
+// range arguments
+func NewCalVer(format, r string) (*CalVer, error) {
+	p := &CalVer{
+		Format: format,
+		Range:  r,
+	}
+	if err := p.parseFormat(); err != nil {
+		return nil, err
+	}
+	if err := p.parseRange(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Latest returns latest version from a provided list of strings
+func (p *CalVer) Latest(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("version list argument cannot be empty")
+	}
+
+	var latest string
+	var latestVersion []int
+	for _, tag := range versions {
+		v, ok := p.parse(tag)
+		if !ok || !p.check(v) {
+			continue
+		}
+		if latestVersion == nil {
+			latest, latestVersion = tag, v
+			continue
+		}
+		if c := compareCalVer(v, latestVersion); c > 0 || (c == 0 && tag > latest) {
+			latest, latestVersion = tag, v
+		}
+	}
+
+	if latestVersion != nil {
+		return latest, nil
+	}
+	return "", fmt.Errorf("unable to determine latest version from provided list")
+}
+
+// Rank returns the versions matching the format and range from a provided
+// list of strings, ordered from the latest to the oldest
+func (p *CalVer) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	type calver struct {
+		tag     string
+		version []int
+	}
+	var parsed []calver
+	for _, tag := range versions {
+		if v, ok := p.parse(tag); ok && p.check(v) {
+			parsed = append(parsed, calver{tag: tag, version: v})
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list")
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		if c := compareCalVer(parsed[i].version, parsed[j].version); c != 0 {
+			return c > 0
+		}
+		return parsed[i].tag > parsed[j].tag
+	})
+	ranked := make([]string, 0, len(parsed))
+	for _, v := range parsed {
+		ranked = append(ranked, v.tag)
+	}
+	return ranked, nil
+}
+
+// Compare compares two versions matching the format regardless of the range
+func (p *CalVer) Compare(a, b string) (int, bool) {
+	va, okA := p.parse(a)
+	vb, okB := p.parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if c := compareCalVer(va, vb); c != 0 {
+		return c, true
+	}
+	return strings.Compare(a, b), true
+}
+
+// parseFormat compiles the format into a regular expression with one capture
+// group per token.
+func (p *CalVer) parseFormat() error {
+	if p.Format == "" {
+		return fmt.Errorf("calver format cannot be empty")
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	rest := p.Format
+	for len(rest) > 0 {
+		matched := false
+		for _, t := range calverTokens {
+			if strings.HasPrefix(rest, t.name) {
+				fmt.Fprintf(&expr, "(%s)", t.pattern)
+				p.years = append(p.years, t.year)
+				rest = rest[len(t.name):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if c := rest[0]; c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			return fmt.Errorf("invalid calver format '%s': unknown token at '%s'", p.Format, rest)
+		}
+		expr.WriteString(regexp.QuoteMeta(rest[:1]))
+		rest = rest[1:]
+	}
+	expr.WriteString("$")
+
+	if len(p.years) == 0 {
+		return fmt.Errorf("invalid calver format '%s': no tokens found", p.Format)
+	}
+
+	m, err := regexp.Compile(expr.String())
+	if err != nil {
+		return fmt.Errorf("invalid calver format '%s': %w", p.Format, err)
+	}
+	p.regexp = m
+	return nil
+}
+
+// parseRange parses the comma or whitespace separated list of constraints.
+func (p *CalVer) parseRange() error {
+	for _, field := range splitConstraints(p.Range) {
+		m := calverConstraintPattern.FindStringSubmatch(field)
+		if m == nil {
+			return fmt.Errorf("invalid calver range '%s': cannot parse constraint '%s'", p.Range, field)
+		}
+		op := m[1]
+		if op == "" {
+			op = "="
+		}
+		version := calverNumbers(m[2])
+		if len(version) > len(p.years) {
+			return fmt.Errorf("invalid calver range '%s': constraint '%s' has more components than format '%s'", p.Range, field, p.Format)
+		}
+		for i := range version {
+			version[i] = p.normalize(i, version[i])
+		}
+		p.constraints = append(p.constraints, calverConstraint{op: op, version: version})
+	}
+	return nil
+}
+
+// parse returns the numeric components of the given tag if it matches the
+// format.
+func (p *CalVer) parse(tag string) ([]int, bool) {
+	m := p.regexp.FindStringSubmatch(tag)
+	if m == nil {
+		return nil, false
+	}
+	v := make([]int, 0, len(m)-1)
+	for i, s := range m[1:] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, false
+		}
+		v = append(v, p.normalize(i, n))
+	}
+	return v, true
+}
+
+// normalize turns short years into full years so that tags and constraints
+// can be compared regardless of the year token used.
+func (p *CalVer) normalize(i, n int) int {
+	if p.years[i] && n < 1000 {
+		return n + 2000
+	}
+	return n
+}
+
+// check reports whether the given version satisfies all the constraints.
+func (p *CalVer) check(v []int) bool {
+	for _, c := range p.constraints {
+		r := compareCalVer(v[:len(c.version)], c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = r == 0
+		case "!=":
+			ok = r != 0
+		case ">":
+			ok = r > 0
+		case ">=":
+			ok = r >= 0
+		case "<":
+			ok = r < 0
+		case "<=":
+			ok = r <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCalVer compares two versions of the same length component by
+// component.
+func compareCalVer(a, b []int) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+var calverNumberPattern = regexp.MustCompile(`[0-9]+`)
+
+// calverNumbers returns the numbers found in the given constraint version.
+func calverNumbers(s string) []int {
+	var result []int
+	for _, n := range calverNumberPattern.FindAllString(s, -1) {
+		i, _ := strconv.Atoi(n)
+		result = append(result, i)
+	}
+	return result
+}
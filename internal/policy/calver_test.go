@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewCalVer(t *testing.T) {
+	cases := []struct {
+		label     string
+		format    string
+		calverRng string
+		expectErr bool
+	}{
+		{
+			label:  "With valid format",
+			format: "YYYY.0M.MICRO",
+		},
+		{
+			label:  "With valid format and separators",
+			format: "YY.MM.DD-MICRO",
+		},
+		{
+			label:  "With build counter",
+			format: "YY.MM.DD-N",
+		},
+		{
+			label:  "With literal prefix",
+			format: "vYYYY.0M.0D",
+		},
+		{
+			label:     "With valid range",
+			format:    "YYYY.0M.MICRO",
+			calverRng: ">=2024.01, <2025",
+		},
+		{
+			label:     "With space separated range",
+			format:    "YYYY.0M.MICRO",
+			calverRng: ">= 2024.01 < 2025",
+		},
+		{
+			label:     "With empty format",
+			format:    "",
+			expectErr: true,
+		},
+		{
+			label:     "With unknown token",
+			format:    "YYYY.QQ",
+			expectErr: true,
+		},
+		{
+			label:     "Without tokens",
+			format:    "latest",
+			expectErr: true,
+		},
+		{
+			label:     "With invalid range",
+			format:    "YYYY.0M.MICRO",
+			calverRng: ">=abc",
+			expectErr: true,
+		},
+		{
+			label:     "With range longer than format",
+			format:    "YYYY.0M",
+			calverRng: ">=2024.01.01",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			_, err := NewCalVer(tt.format, tt.calverRng)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestCalVer_Latest(t *testing.T) {
+	cases := []struct {
+		label           string
+		format          string
+		calverRng       string
+		versions        []string
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			label:           "With unpadded months and micro",
+			format:          "YYYY.MM.MICRO",
+			versions:        []string{"2024.9.10", "2024.10.1", "2024.10.0", "2023.12.99"},
+			expectedVersion: "2024.10.1",
+		},
+		{
+			label:           "With padded months",
+			format:          "YYYY.0M.MICRO",
+			versions:        []string{"2024.09.10", "2024.10.1", "2024.9.11", "latest"},
+			expectedVersion: "2024.10.1",
+		},
+		{
+			label:           "With short years and days",
+			format:          "YY.MM.DD-MICRO",
+			versions:        []string{"24.12.31-0", "25.1.2-0", "25.1.2-3", "25.1.10-1"},
+			expectedVersion: "25.1.10-1",
+		},
+		{
+			label:           "With build counter",
+			format:          "YY.MM.DD-N",
+			versions:        []string{"24.5.1-9", "24.5.1-10", "24.4.30-11", "24.5.1"},
+			expectedVersion: "24.5.1-10",
+		},
+		{
+			label:           "With build counter and range",
+			format:          "YYYY.0M.0D.N",
+			calverRng:       "<2024.05.01.3",
+			versions:        []string{"2024.05.01.2", "2024.05.01.3", "2024.04.30.7"},
+			expectedVersion: "2024.05.01.2",
+		},
+		{
+			label:           "With lower bound",
+			format:          "YYYY.0M.MICRO",
+			calverRng:       ">=2024.01",
+			versions:        []string{"2023.12.5", "2024.01.0"},
+			expectedVersion: "2024.01.0",
+		},
+		{
+			label:           "With upper bound",
+			format:          "YYYY.0M.MICRO",
+			calverRng:       ">=2024.01, <2025",
+			versions:        []string{"2023.12.5", "2024.11.0", "2025.01.0"},
+			expectedVersion: "2024.11.0",
+		},
+		{
+			label:           "With equality on prefix",
+			format:          "YYYY.0M.MICRO",
+			calverRng:       "2024.05",
+			versions:        []string{"2024.05.1", "2024.05.12", "2024.06.0"},
+			expectedVersion: "2024.05.12",
+		},
+		{
+			label:           "With short year range against full year format",
+			format:          "YYYY.MM",
+			calverRng:       ">=24.6",
+			versions:        []string{"2024.5", "2024.7"},
+			expectedVersion: "2024.7",
+		},
+		{
+			label:     "With no matching version",
+			format:    "YYYY.0M.MICRO",
+			calverRng: ">=2026",
+			versions:  []string{"2024.05.1", "2025.01.0"},
+			expectErr: true,
+		},
+		{
+			label:     "With invalid month",
+			format:    "YYYY.MM",
+			versions:  []string{"2024.13", "2024.0"},
+			expectErr: true,
+		},
+		{
+			label:     "Empty version list",
+			format:    "YYYY.0M.MICRO",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewCalVer(tt.format, tt.calverRng)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			latest, err := policy.Latest(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if latest != tt.expectedVersion {
+				t.Errorf("incorrect computed version returned, got '%s', expected '%s'", latest, tt.expectedVersion)
+			}
+		})
+	}
+}
+
+func TestCalVer_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		format         string
+		calverRange    string
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label:          "With format and range",
+			versions:       []string{"2023.12.1", "2024.9.10", "2024.10.1", "2024.10.0", "latest"},
+			format:         "YYYY.MM.MICRO",
+			calverRange:    ">=2024",
+			expectedRanked: []string{"2024.10.1", "2024.10.0", "2024.9.10"},
+		},
+		{
+			label:     "With no matching version",
+			versions:  []string{"latest"},
+			format:    "YYYY.MM.MICRO",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewCalVer(tt.format, tt.calverRange)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import "strings"
+
+// splitConstraints splits a comma or whitespace separated list of
+// comparison constraints, joining operators with the version that follows
+// them, e.g. '>= 1.0, < 2.0' into '>=1.0' and '<2.0'.
+func splitConstraints(s string) []string {
+	var result []string
+	for _, c := range strings.Split(s, ",") {
+		var op string
+		for _, field := range strings.Fields(c) {
+			if strings.Trim(field, "<>=!") == "" {
+				op += field
+				continue
+			}
+			result = append(result, op+field)
+			op = ""
+		}
+		if op != "" {
+			result = append(result, op)
+		}
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitConstraints(t *testing.T) {
+	cases := []struct {
+		constraints string
+		expected    []string
+	}{
+		{constraints: "", expected: nil},
+		{constraints: ">=1.0", expected: []string{">=1.0"}},
+		{constraints: ">=1.0 <2.0", expected: []string{">=1.0", "<2.0"}},
+		{constraints: ">= 1.0, < 2.0", expected: []string{">=1.0", "<2.0"}},
+		{constraints: "!= 1.5,,=2.0", expected: []string{"!=1.5", "=2.0"}},
+		{constraints: "1.0 >=", expected: []string{"1.0", ">="}},
+		{constraints: ">=, 1.0", expected: []string{">=", "1.0"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.constraints, func(t *testing.T) {
+			if got := splitConstraints(tt.constraints); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("incorrect constraints returned, got '%v', expected '%v'", got, tt.expected)
+			}
+		})
+	}
+}
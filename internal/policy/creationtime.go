@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CreationTime represents a policy ordering tags by the creation time of
+// the images they point to
+type CreationTime struct {
+	created map[string]time.Time
+}
+
+// NewCreationTime constructs a CreationTime object
+func NewCreationTime() *CreationTime {
+	return &CreationTime{}
+}
+
+// SetCreationTimes sets the image creation time of each tag. Tags without a
+// creation time are ignored by Latest.
+func (p *CreationTime) SetCreationTimes(created map[string]time.Time) {
+	p.created = created
+}
+
+// Latest returns the tag with the newest image from a provided list of strings
+func (p *CreationTime) Latest(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("version list argument cannot be empty")
+	}
+
+	var latest string
+	var latestTime time.Time
+	for _, tag := range versions {
+		t, ok := p.created[tag]
+		if !ok || t.IsZero() {
+			continue
+		}
+		if latest == "" || t.After(latestTime) || (t.Equal(latestTime) && tag > latest) {
+			latest, latestTime = tag, t
+		}
+	}
+
+	if latest != "" {
+		return latest, nil
+	}
+	return "", fmt.Errorf("unable to determine latest version from provided list: no image creation time found")
+}
+
+// Compare compares the creation times of the images two tags point to
+func (p *CreationTime) Compare(a, b string) (int, bool) {
+	ta, okA := p.created[a]
+	tb, okB := p.created[b]
+	if !okA || !okB || ta.IsZero() || tb.IsZero() {
+		return 0, false
+	}
+	if c := ta.Compare(tb); c != 0 {
+		return c, true
+	}
+	return strings.Compare(a, b), true
+}
+
+// Rank returns the tags with a creation time from a provided list of strings,
+// ordered from the newest to the oldest image
+func (p *CreationTime) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	var ranked []string
+	for _, tag := range versions {
+		if t, ok := p.created[tag]; ok && !t.IsZero() {
+			ranked = append(ranked, tag)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list: no image creation time found")
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ti, tj := p.created[ranked[i]], p.created[ranked[j]]
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return ranked[i] > ranked[j]
+	})
+	return ranked, nil
+}
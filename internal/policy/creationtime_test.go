@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCreationTime_Latest(t *testing.T) {
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		label           string
+		created         map[string]time.Time
+		versions        []string
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			label: "With newest image",
+			created: map[string]time.Time{
+				"main-abc": base,
+				"main-def": base.Add(time.Hour),
+				"main-123": base.Add(-time.Hour),
+			},
+			versions:        []string{"main-abc", "main-def", "main-123"},
+			expectedVersion: "main-def",
+		},
+		{
+			label: "With equal creation times",
+			created: map[string]time.Time{
+				"a": base,
+				"b": base,
+			},
+			versions:        []string{"a", "b"},
+			expectedVersion: "b",
+		},
+		{
+			label: "With missing creation times",
+			created: map[string]time.Time{
+				"a": base,
+				"b": {},
+			},
+			versions:        []string{"a", "b", "c"},
+			expectedVersion: "a",
+		},
+		{
+			label:     "With no creation times",
+			versions:  []string{"a", "b"},
+			expectErr: true,
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy := NewCreationTime()
+			policy.SetCreationTimes(tt.created)
+			latest, err := policy.Latest(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if latest != tt.expectedVersion {
+				t.Errorf("incorrect computed version returned, got '%s', expected '%s'", latest, tt.expectedVersion)
+			}
+		})
+	}
+}
+
+func TestCreationTime_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		created        map[string]time.Time
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label: "With creation times",
+			created: map[string]time.Time{
+				"a": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				"b": time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				"c": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				"d": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			versions:       []string{"a", "b", "c", "d", "e"},
+			expectedRanked: []string{"b", "d", "c", "a"},
+		},
+		{
+			label:     "With no creation times",
+			versions:  []string{"a"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy := NewCreationTime()
+			policy.SetCreationTimes(tt.created)
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
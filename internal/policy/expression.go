@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+// Variables available to the tag filter expressions.
+const (
+	// ExpressionVarTag holds the tag.
+	ExpressionVarTag = "tag"
+	// ExpressionVarValue holds the value extracted from the tag, or the tag
+	// itself if no extract template is set.
+	ExpressionVarValue = "value"
+	// ExpressionVarCaptures holds the capture groups of the filter pattern
+	// keyed by name, and by index for all of them.
+	ExpressionVarCaptures = "captures"
+	// ExpressionVarSemVer holds the major, minor, patch, prerelease and
+	// metadata fields of the value parsed as a semantic version, or an empty
+	// map if it is not one.
+	ExpressionVarSemVer = "semver"
+	// ExpressionVarFirstSeen holds the time the tag was first seen in the
+	// image repository.
+	ExpressionVarFirstSeen = "firstSeen"
+	// ExpressionVarPolicy holds the name and namespace of the image policy.
+	ExpressionVarPolicy = "policy"
+)
+
+const (
+	// expressionCostLimit bounds the cost of evaluating an expression for a
+	// tag, like the per call limit of the Kubernetes validation rules.
+	expressionCostLimit = 1000000
+	// expressionTimeout bounds the time spent evaluating an expression for
+	// a tag.
+	expressionTimeout = time.Second
+	// expressionCacheSize bounds the number of compiled expressions kept
+	// for reuse across reconciliations.
+	expressionCacheSize = 256
+)
+
+var (
+	// expressionEnv holds the CEL environment shared by all the
+	// expressions, which is safe for concurrent use.
+	expressionEnv = sync.OnceValues(newExpressionEnv)
+
+	expressionCacheMu sync.Mutex
+	expressionCache   = make(map[string]*TagExpression)
+)
+
+// TagExpression represents a CEL predicate evaluated for each tag
+type TagExpression struct {
+	expr string
+	prog cel.Program
+}
+
+// TagEnv holds the values of the expression variables which do not depend
+// on the filter pattern
+type TagEnv struct {
+	Name      string
+	Namespace string
+	// FirstSeen holds the time each tag was first seen, tags missing from it
+	// are considered as first seen at Now.
+	FirstSeen map[string]time.Time
+	Now       time.Time
+}
+
+// NewTagExpression parses and type-checks the given CEL expression, which
+// must evaluate to a boolean. Compiled expressions are cached, so that they
+// are not compiled again on every reconciliation.
+func NewTagExpression(expr string) (*TagExpression, error) {
+	expressionCacheMu.Lock()
+	defer expressionCacheMu.Unlock()
+	if e, ok := expressionCache[expr]; ok {
+		return e, nil
+	}
+
+	e, err := compileTagExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(expressionCache) >= expressionCacheSize {
+		clear(expressionCache)
+	}
+	expressionCache[expr] = e
+	return e, nil
+}
+
+// compileTagExpression compiles the given expression into a program whose
+// evaluation is bounded by expressionCostLimit.
+func compileTagExpression(expr string) (*TagExpression, error) {
+	env, err := expressionEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %s", expr, issues.String())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("invalid expression '%s': must evaluate to a bool, got %s", expr, ast.OutputType())
+	}
+
+	prog, err := env.Program(ast,
+		cel.EvalOptions(cel.OptOptimize),
+		cel.CostLimit(expressionCostLimit),
+		cel.InterruptCheckFrequency(100),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", expr, err)
+	}
+	return &TagExpression{expr: expr, prog: prog}, nil
+}
+
+// newExpressionEnv creates the CEL environment declaring the expression
+// variables.
+func newExpressionEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.HomogeneousAggregateLiterals(),
+		cel.EagerlyValidateDeclarations(true),
+		cel.DefaultUTCTimeZone(true),
+		cel.CrossTypeNumericComparisons(true),
+		cel.OptionalTypes(),
+		ext.Strings(),
+		cel.Variable(ExpressionVarTag, cel.StringType),
+		cel.Variable(ExpressionVarValue, cel.StringType),
+		cel.Variable(ExpressionVarCaptures, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(ExpressionVarSemVer, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(ExpressionVarFirstSeen, cel.TimestampType),
+		cel.Variable(ExpressionVarPolicy, cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	return env, nil
+}
+
+// String returns the original expression
+func (e *TagExpression) String() string {
+	return e.expr
+}
+
+// Matches evaluates the expression for the given tag, value extracted from
+// it and named capture groups. The evaluation is interrupted if it exceeds
+// expressionTimeout.
+func (e *TagExpression) Matches(ctx context.Context, tag, value string, captures map[string]string, env TagEnv) (bool, error) {
+	firstSeen, ok := env.FirstSeen[tag]
+	if !ok {
+		firstSeen = env.Now
+	}
+	ctx, cancel := context.WithTimeout(ctx, expressionTimeout)
+	defer cancel()
+	val, _, err := e.prog.ContextEval(ctx, map[string]any{
+		ExpressionVarTag:       tag,
+		ExpressionVarValue:     value,
+		ExpressionVarCaptures:  captures,
+		ExpressionVarSemVer:    semverFields(value),
+		ExpressionVarFirstSeen: firstSeen,
+		ExpressionVarPolicy:    map[string]string{"name": env.Name, "namespace": env.Namespace},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression '%s' for tag '%s': %w", e.expr, tag, err)
+	}
+	result, ok := val.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("failed to evaluate expression '%s' for tag '%s': not a bool", e.expr, tag)
+	}
+	return bool(result), nil
+}
+
+// semverFields returns the fields of the given value parsed as a semantic
+// version, or an empty map if it is not one.
+func semverFields(value string) map[string]any {
+	ver, err := semver.NewVersion(value)
+	if err != nil {
+		return map[string]any{}
+	}
+	return map[string]any{
+		"major":      int64(ver.Major()),
+		"minor":      int64(ver.Minor()),
+		"patch":      int64(ver.Patch()),
+		"prerelease": ver.Prerelease(),
+		"metadata":   ver.Metadata(),
+	}
+}
+
+// captureGroups returns the capture groups of the given submatch indexes,
+// keyed by index and by name for the named ones. Groups which did not
+// participate in the match are omitted.
+func captureGroups(names []string, item string, submatches []int) map[string]string {
+	captures := make(map[string]string, len(names))
+	for i, name := range names {
+		start, end := submatches[2*i], submatches[2*i+1]
+		if start < 0 {
+			continue
+		}
+		captures[strconv.Itoa(i)] = item[start:end]
+		if name != "" {
+			captures[name] = item[start:end]
+		}
+	}
+	return captures
+}
@@ -29,11 +29,38 @@ func PolicerFromSpec(choice imagev1.ImagePolicyChoice) (Policer, error) {
 	var err error
 	switch {
 	case choice.SemVer != nil:
-		p, err = NewSemVer(choice.SemVer.Range)
+		var sv *SemVer
+		if choice.SemVer.Tracks != "" {
+			sv, err = NewSemVerTracks(choice.SemVer.Range, choice.SemVer.Tracks)
+		} else {
+			sv, err = NewSemVer(choice.SemVer.Range)
+		}
+		if err == nil {
+			sv.Variant = choice.SemVer.Variant
+			sv.Prereleases = choice.SemVer.IncludePrereleases
+			p = sv
+		}
 	case choice.Alphabetical != nil:
-		p, err = NewAlphabetical(strings.ToUpper(choice.Alphabetical.Order))
+		var a *Alphabetical
+		if a, err = NewAlphabetical(strings.ToUpper(choice.Alphabetical.Order)); err == nil {
+			a.Natural = choice.Alphabetical.Collation == imagev1.AlphabeticalCollationNatural
+			a.CaseInsensitive = choice.Alphabetical.CaseInsensitive
+			p = a
+		}
 	case choice.Numerical != nil:
-		p, err = NewNumerical(strings.ToUpper(choice.Numerical.Order))
+		var n *Numerical
+		if n, err = NewNumerical(strings.ToUpper(choice.Numerical.Order)); err == nil {
+			n.SkipInvalid = choice.Numerical.SkipInvalid
+			p = n
+		}
+	case choice.CalVer != nil:
+		p, err = NewCalVer(choice.CalVer.Format, choice.CalVer.Range)
+	case choice.CreationTime != nil:
+		p = NewCreationTime()
+	case choice.Version != nil:
+		p, err = NewVersion(choice.Version.Range)
+	case choice.FloatingTag != nil:
+		p, err = NewFloatingTag(choice.FloatingTag.Tag)
 	default:
 		return nil, fmt.Errorf("given ImagePolicyChoice object is invalid")
 	}
@@ -43,3 +70,41 @@ func PolicerFromSpec(choice imagev1.ImagePolicyChoice) (Policer, error) {
 	}
 	return p, nil
 }
+
+// FilterFromSpec constructs a new RegexFilter object based on the given
+// TagFilter
+func FilterFromSpec(filter imagev1.TagFilter) (*RegexFilter, error) {
+	f, err := NewRegexFilter(filter.Pattern, filter.Extract)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.SetIncludeExclude(filter.Include, filter.Exclude); err != nil {
+		return nil, err
+	}
+	if err := f.SetCollisionStrategy(filter.CollisionStrategy); err != nil {
+		return nil, err
+	}
+	if filter.Expression != "" {
+		if err := f.SetExpression(filter.Expression); err != nil {
+			return nil, err
+		}
+	}
+	if len(filter.SortKeys) == 0 {
+		return f, nil
+	}
+
+	keys := make([]SortKey, 0, len(filter.SortKeys))
+	for _, k := range filter.SortKeys {
+		keys = append(keys, SortKey{
+			Group:    k.Group,
+			Type:     k.Type,
+			Order:    strings.ToUpper(k.Order),
+			Layout:   k.Layout,
+			Priority: k.Priority,
+		})
+	}
+	if err := f.SetSortKeys(keys); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
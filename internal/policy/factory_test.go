@@ -41,6 +41,73 @@ func TestFactory_PolicerFromSpec(t *testing.T) {
 		t.Error("should not return error")
 	}
 
+	// With SemVerPolicy tracks
+	tracker, err := PolicerFromSpec(imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: ">=1.0.0", Tracks: "minor"}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if _, ok := tracker.(Tracker); !ok {
+		t.Error("should be a Tracker")
+	}
+
+	// With SemVerPolicy using a variant and prerelease channels
+	semVer, err := PolicerFromSpec(imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{
+		Range:              ">=1.0.0",
+		Variant:            "alpine",
+		IncludePrereleases: []string{"rc"},
+	}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if sv, ok := semVer.(*SemVer); !ok || sv.Variant != "alpine" || len(sv.Prereleases) != 1 {
+		t.Error("should use the variant and prerelease channels")
+	}
+
+	// With AlphabeticalPolicy using natural collation
+	alphabetical, err := PolicerFromSpec(imagev1.ImagePolicyChoice{Alphabetical: &imagev1.AlphabeticalPolicy{
+		Collation:       imagev1.AlphabeticalCollationNatural,
+		CaseInsensitive: true,
+	}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if a, ok := alphabetical.(*Alphabetical); !ok || !a.Natural || !a.CaseInsensitive {
+		t.Error("should use case-insensitive natural collation")
+	}
+
+	// With NumericalPolicy skipping invalid values
+	numerical, err := PolicerFromSpec(imagev1.ImagePolicyChoice{Numerical: &imagev1.NumericalPolicy{SkipInvalid: true}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if n, ok := numerical.(*Numerical); !ok || !n.SkipInvalid {
+		t.Error("should skip invalid values")
+	}
+
+	// With CalVerPolicy
+	_, err = PolicerFromSpec(imagev1.ImagePolicyChoice{CalVer: &imagev1.CalVerPolicy{Format: "YYYY.0M.MICRO"}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+
+	// With CreationTimePolicy
+	_, err = PolicerFromSpec(imagev1.ImagePolicyChoice{CreationTime: &imagev1.CreationTimePolicy{}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+
+	// With VersionPolicy
+	_, err = PolicerFromSpec(imagev1.ImagePolicyChoice{Version: &imagev1.VersionPolicy{Range: ">=10.0.17763"}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+
+	// With FloatingTagPolicy
+	_, err = PolicerFromSpec(imagev1.ImagePolicyChoice{FloatingTag: &imagev1.FloatingTagPolicy{Tag: "stable"}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+
 	// A nil checkable Policer for invalid policy.
 	p, err := PolicerFromSpec(imagev1.ImagePolicyChoice{SemVer: &imagev1.SemVerPolicy{Range: "*-*"}})
 	if err == nil {
@@ -50,3 +117,84 @@ func TestFactory_PolicerFromSpec(t *testing.T) {
 		t.Error("should be nil")
 	}
 }
+
+func TestFactory_FilterFromSpec(t *testing.T) {
+	// With invalid pattern
+	_, err := FilterFromSpec(imagev1.TagFilter{Pattern: "[="})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	// Without sort keys
+	f, err := FilterFromSpec(imagev1.TagFilter{Pattern: "^v"})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if f.SortKeys != nil {
+		t.Error("sort keys should be nil")
+	}
+
+	// With sort keys
+	f, err = FilterFromSpec(imagev1.TagFilter{
+		Pattern:  `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+		Extract:  "$version",
+		SortKeys: []imagev1.TagSortKey{{Group: "build", Type: "numeric", Order: "desc"}},
+	})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if f.SortKeys == nil || f.SortKeys.Keys[0].Order != SortKeyOrderDesc {
+		t.Error("sort keys should be set")
+	}
+
+	// With include and exclude patterns
+	f, err = FilterFromSpec(imagev1.TagFilter{Include: []string{"^v"}, Exclude: []string{"-debug$", "-rc"}})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if len(f.Include) != 1 || len(f.Exclude) != 2 {
+		t.Error("include and exclude patterns should be set")
+	}
+
+	// With invalid exclude pattern
+	_, err = FilterFromSpec(imagev1.TagFilter{Exclude: []string{"[="}})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	// With invalid collision strategy
+	_, err = FilterFromSpec(imagev1.TagFilter{CollisionStrategy: "invalid"})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	// With expression
+	f, err = FilterFromSpec(imagev1.TagFilter{Expression: `tag != "latest"`})
+	if err != nil {
+		t.Error("should not return error")
+	}
+	if f.Expression == nil {
+		t.Error("expression should be set")
+	}
+
+	// With invalid expression
+	_, err = FilterFromSpec(imagev1.TagFilter{Expression: `tag ==`})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	// With expression not evaluating to a bool
+	_, err = FilterFromSpec(imagev1.TagFilter{Expression: `tag`})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+
+	// With sort key referring to an unknown group
+	_, err = FilterFromSpec(imagev1.TagFilter{
+		Pattern:  `^(?P<version>[0-9.]+)$`,
+		SortKeys: []imagev1.TagSortKey{{Group: "build", Type: "numeric"}},
+	})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
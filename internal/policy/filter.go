@@ -17,16 +17,37 @@ limitations under the License.
 package policy
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"sort"
+	"time"
+)
+
+const (
+	// CollisionStrategyGreatest selects the lexicographically greatest tag
+	CollisionStrategyGreatest = "Greatest"
+	// CollisionStrategyNewestFirstSeen selects the tag first seen most
+	// recently
+	CollisionStrategyNewestFirstSeen = "NewestFirstSeen"
+	// CollisionStrategyFail rejects the collisions
+	CollisionStrategyFail = "Fail"
 )
 
 // RegexFilter represents a regular expression filter
 type RegexFilter struct {
-	filtered map[string]string
+	filtered map[string][]string
+
+	Regexp     *regexp.Regexp
+	Replace    string
+	Include    []*regexp.Regexp
+	Exclude    []*regexp.Regexp
+	SortKeys   *MultiKey
+	Expression *TagExpression
+	Collision  string
 
-	Regexp  *regexp.Regexp
-	Replace string
+	firstSeen map[string]time.Time
+	now       time.Time
 }
 
 // NewRegexFilter constructs new RegexFilter object
@@ -41,22 +62,154 @@ func NewRegexFilter(pattern string, replace string) (*RegexFilter, error) {
 	}, nil
 }
 
+// SetIncludeExclude configures the lists of patterns the tags must match at
+// least one of, and none of. They are applied before the value is extracted
+func (f *RegexFilter) SetIncludeExclude(include, exclude []string) error {
+	var err error
+	if f.Include, err = compilePatterns(include); err != nil {
+		return err
+	}
+	if f.Exclude, err = compilePatterns(exclude); err != nil {
+		return err
+	}
+	return nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		m, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression pattern '%s': %w", pattern, err)
+		}
+		compiled = append(compiled, m)
+	}
+	return compiled, nil
+}
+
+// SetCollisionStrategy configures how the original tag is selected when
+// several tags share the same extracted value and no sort keys are configured
+func (f *RegexFilter) SetCollisionStrategy(strategy string) error {
+	switch strategy {
+	case "", CollisionStrategyGreatest, CollisionStrategyNewestFirstSeen, CollisionStrategyFail:
+		f.Collision = strategy
+		return nil
+	default:
+		return fmt.Errorf("invalid collision strategy '%s', must be one of: %s, %s, %s",
+			strategy, CollisionStrategyGreatest, CollisionStrategyNewestFirstSeen, CollisionStrategyFail)
+	}
+}
+
+// SetFirstSeen sets the time each tag was first seen, used by the
+// NewestFirstSeen collision strategy. Tags missing from it are considered as
+// first seen at now.
+func (f *RegexFilter) SetFirstSeen(firstSeen map[string]time.Time, now time.Time) {
+	f.firstSeen = firstSeen
+	f.now = now
+}
+
+// SetSortKeys configures the keys used to order the original tags which share
+// the same extracted value
+func (f *RegexFilter) SetSortKeys(keys []SortKey) error {
+	m, err := NewMultiKey(f.Regexp, keys)
+	if err != nil {
+		return err
+	}
+	f.SortKeys = m
+	return nil
+}
+
+// SetExpression configures the CEL expression the tags matching the pattern
+// must satisfy
+func (f *RegexFilter) SetExpression(expr string) error {
+	e, err := NewTagExpression(expr)
+	if err != nil {
+		return err
+	}
+	f.Expression = e
+	return nil
+}
+
+// Select returns the tags of the provided list matching the pattern for
+// which the expression holds. The list is returned as is if no expression is
+// configured.
+func (f *RegexFilter) Select(ctx context.Context, list []string, env TagEnv) ([]string, error) {
+	if f.Expression == nil {
+		return list, nil
+	}
+
+	var selected []string
+	for _, item := range list {
+		submatches := f.match(item)
+		if len(submatches) == 0 {
+			continue
+		}
+		captures := captureGroups(f.Regexp.SubexpNames(), item, submatches)
+		ok, err := f.Expression.Matches(ctx, item, f.extract(item, submatches), captures, env)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selected = append(selected, item)
+		}
+	}
+	return selected, nil
+}
+
+// ExtractValue returns the value extracted from the given tag, and whether
+// the tag passes the filter patterns
+func (f *RegexFilter) ExtractValue(tag string) (string, bool) {
+	submatches := f.match(tag)
+	if len(submatches) == 0 {
+		return "", false
+	}
+	return f.extract(tag, submatches), true
+}
+
 // Apply will construct the filtered list of tags based on the provided list of tags
 func (f *RegexFilter) Apply(list []string) {
-	f.filtered = map[string]string{}
+	f.filtered = map[string][]string{}
 	for _, item := range list {
-		if submatches := f.Regexp.FindStringSubmatchIndex(item); len(submatches) > 0 {
-			tag := item
-			if f.Replace != "" {
-				result := []byte{}
-				result = f.Regexp.ExpandString(result, f.Replace, item, submatches)
-				tag = string(result)
-			}
-			f.filtered[tag] = item
+		if submatches := f.match(item); len(submatches) > 0 {
+			tag := f.extract(item, submatches)
+			f.filtered[tag] = append(f.filtered[tag], item)
 		}
 	}
 }
 
+// match returns the submatch indexes of the pattern in the given tag, or nil
+// if the tag does not match the pattern, does not match any of the include
+// patterns, or matches one of the exclude patterns.
+func (f *RegexFilter) match(item string) []int {
+	if len(f.Include) > 0 && !matchAny(f.Include, item) {
+		return nil
+	}
+	if matchAny(f.Exclude, item) {
+		return nil
+	}
+	return f.Regexp.FindStringSubmatchIndex(item)
+}
+
+func matchAny(patterns []*regexp.Regexp, item string) bool {
+	for _, m := range patterns {
+		if m.MatchString(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// extract returns the value extracted from the given tag matching the
+// pattern, or the tag itself if no extract template is configured.
+func (f *RegexFilter) extract(item string, submatches []int) string {
+	if f.Replace == "" {
+		return item
+	}
+	result := []byte{}
+	result = f.Regexp.ExpandString(result, f.Replace, item, submatches)
+	return string(result)
+}
+
 // Items returns the list of filtered tags
 func (f *RegexFilter) Items() []string {
 	var filtered []string
@@ -66,7 +219,69 @@ func (f *RegexFilter) Items() []string {
 	return filtered
 }
 
-// GetOriginalTag returns the original tag before replace extraction
+// GetOriginalTag returns the original tag before replace extraction. When
+// several original tags share the same extracted value, the highest one
+// according to the sort keys is returned. Without sort keys, the collision
+// strategy selects it, or the last one is returned if none is configured.
 func (f *RegexFilter) GetOriginalTag(tag string) string {
-	return f.filtered[tag]
+	originals := f.filtered[tag]
+	if len(originals) == 0 {
+		return ""
+	}
+	if f.SortKeys != nil {
+		if latest, err := f.SortKeys.Latest(originals); err == nil {
+			return latest
+		}
+	}
+
+	switch f.Collision {
+	case CollisionStrategyGreatest:
+		latest := originals[0]
+		for _, original := range originals[1:] {
+			if original > latest {
+				latest = original
+			}
+		}
+		return latest
+	case CollisionStrategyNewestFirstSeen:
+		seen := func(original string) time.Time {
+			if t, ok := f.firstSeen[original]; ok {
+				return t
+			}
+			return f.now
+		}
+		latest := originals[0]
+		for _, original := range originals[1:] {
+			if t, lt := seen(original), seen(latest); t.After(lt) || (t.Equal(lt) && original > latest) {
+				latest = original
+			}
+		}
+		return latest
+	}
+	return originals[len(originals)-1]
+}
+
+// Collisions returns the original tags sharing the given extracted value in
+// lexicographical order, or nil if there is at most one.
+func (f *RegexFilter) Collisions(tag string) []string {
+	originals := f.filtered[tag]
+	if len(originals) < 2 {
+		return nil
+	}
+	collisions := append([]string(nil), originals...)
+	sort.Strings(collisions)
+	return collisions
+}
+
+// CheckCollision returns an error if several original tags share the given
+// extracted value while the collision strategy is set to fail. Collisions
+// resolved by the sort keys are accepted.
+func (f *RegexFilter) CheckCollision(tag string) error {
+	if f.Collision != CollisionStrategyFail || f.SortKeys != nil {
+		return nil
+	}
+	if collisions := f.Collisions(tag); collisions != nil {
+		return fmt.Errorf("tags %v share the extracted value '%s'", collisions, tag)
+	}
+	return nil
 }
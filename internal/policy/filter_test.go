@@ -17,8 +17,12 @@ limitations under the License.
 package policy
 
 import (
+	"context"
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -29,6 +33,8 @@ func TestRegexFilter(t *testing.T) {
 		tags     []string
 		pattern  string
 		extract  string
+		include  []string
+		exclude  []string
 		expected []string
 	}{
 		{
@@ -93,6 +99,27 @@ func TestRegexFilter(t *testing.T) {
 				"123-123.123.abcd456",
 			},
 		},
+		{
+			label:    "exclude patterns",
+			tags:     []string{"1.0.0", "1.0.0-debug", "1.1.0-rc.1", "1.1.0"},
+			exclude:  []string{`-debug$`, `-rc\.`},
+			expected: []string{"1.0.0", "1.1.0"},
+		},
+		{
+			label:    "include patterns",
+			tags:     []string{"main-1", "release-2", "dev-3"},
+			include:  []string{`^main-`, `^release-`},
+			expected: []string{"main-1", "release-2"},
+		},
+		{
+			label:    "include and exclude patterns before extract",
+			tags:     []string{"app-1.0.0", "app-1.1.0-debug", "app-1.2.0", "lib-1.3.0"},
+			pattern:  `-(?P<version>[0-9.]+.*)$`,
+			extract:  `$version`,
+			include:  []string{`^app-`},
+			exclude:  []string{`-debug$`},
+			expected: []string{"1.0.0", "1.2.0"},
+		},
 	}
 
 	for _, tt := range cases {
@@ -101,6 +128,7 @@ func TestRegexFilter(t *testing.T) {
 
 			f, err := NewRegexFilter(tt.pattern, tt.extract)
 			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(f.SetIncludeExclude(tt.include, tt.exclude)).To(Succeed())
 
 			f.Apply(tt.tags)
 			r := f.Items()
@@ -110,3 +138,276 @@ func TestRegexFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestRegexFilter_GetOriginalTag(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		label     string
+		tags      []string
+		pattern   string
+		extract   string
+		keys      []SortKey
+		collision string
+		firstSeen map[string]time.Time
+		tag       string
+		expected  string
+	}{
+		{
+			label:    "without extract",
+			tags:     []string{"ver1", "ver2"},
+			pattern:  "^ver",
+			tag:      "ver2",
+			expected: "ver2",
+		},
+		{
+			label:    "unknown tag",
+			tags:     []string{"ver1", "ver2"},
+			pattern:  "^ver",
+			tag:      "rel1",
+			expected: "",
+		},
+		{
+			label:   "collision with sort keys",
+			tags:    []string{"1.4.2-build.9", "1.4.2-build.37", "1.4.2-build.10"},
+			pattern: `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+			extract: "$version",
+			keys: []SortKey{
+				{Group: "build", Type: SortKeyTypeNumeric},
+			},
+			tag:      "1.4.2",
+			expected: "1.4.2-build.37",
+		},
+		{
+			label:    "collision without strategy",
+			tags:     []string{"1.2.3-arm64", "1.2.3-amd64"},
+			pattern:  `^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`,
+			extract:  "$version",
+			tag:      "1.2.3",
+			expected: "1.2.3-amd64",
+		},
+		{
+			label:     "collision with greatest strategy",
+			tags:      []string{"1.2.3-arm64", "1.2.3-amd64"},
+			pattern:   `^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`,
+			extract:   "$version",
+			collision: CollisionStrategyGreatest,
+			tag:       "1.2.3",
+			expected:  "1.2.3-arm64",
+		},
+		{
+			label:     "collision with newest first seen strategy",
+			tags:      []string{"1.2.3-arm64", "1.2.3-amd64", "1.2.3-s390x"},
+			pattern:   `^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`,
+			extract:   "$version",
+			collision: CollisionStrategyNewestFirstSeen,
+			firstSeen: map[string]time.Time{
+				"1.2.3-arm64": now.Add(-2 * time.Hour),
+				"1.2.3-amd64": now.Add(-time.Hour),
+				"1.2.3-s390x": now.Add(-3 * time.Hour),
+			},
+			tag:      "1.2.3",
+			expected: "1.2.3-amd64",
+		},
+		{
+			label:     "collision with newest first seen strategy and unrecorded tag",
+			tags:      []string{"1.2.3-arm64", "1.2.3-amd64"},
+			pattern:   `^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`,
+			extract:   "$version",
+			collision: CollisionStrategyNewestFirstSeen,
+			firstSeen: map[string]time.Time{"1.2.3-amd64": now.Add(-time.Hour)},
+			tag:       "1.2.3",
+			expected:  "1.2.3-arm64",
+		},
+		{
+			label:     "collision with sort keys and strategy",
+			tags:      []string{"1.2.3-build.2", "1.2.3-build.10"},
+			pattern:   `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+			extract:   "$version",
+			keys:      []SortKey{{Group: "build", Type: SortKeyTypeNumeric}},
+			collision: CollisionStrategyGreatest,
+			tag:       "1.2.3",
+			expected:  "1.2.3-build.10",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			g := NewWithT(t)
+
+			f, err := NewRegexFilter(tt.pattern, tt.extract)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.keys != nil {
+				g.Expect(f.SetSortKeys(tt.keys)).To(Succeed())
+			}
+			g.Expect(f.SetCollisionStrategy(tt.collision)).To(Succeed())
+			f.SetFirstSeen(tt.firstSeen, now)
+
+			f.Apply(tt.tags)
+			g.Expect(f.GetOriginalTag(tt.tag)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestRegexFilter_CheckCollision(t *testing.T) {
+	g := NewWithT(t)
+
+	f, err := NewRegexFilter(`^(?P<version>[0-9.]+)-(?P<arch>[a-z0-9]+)$`, "$version")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.SetCollisionStrategy("invalid")).ToNot(Succeed())
+
+	f.Apply([]string{"1.2.3-arm64", "1.2.3-amd64", "1.2.4-amd64"})
+	g.Expect(f.Collisions("1.2.3")).To(Equal([]string{"1.2.3-amd64", "1.2.3-arm64"}))
+	g.Expect(f.Collisions("1.2.4")).To(BeNil())
+	g.Expect(f.CheckCollision("1.2.3")).To(Succeed())
+
+	g.Expect(f.SetCollisionStrategy(CollisionStrategyFail)).To(Succeed())
+	g.Expect(f.CheckCollision("1.2.3")).ToNot(Succeed())
+	g.Expect(f.CheckCollision("1.2.4")).To(Succeed())
+
+	// Collisions resolved by sort keys are accepted.
+	g.Expect(f.SetSortKeys([]SortKey{{Group: "arch", Type: SortKeyTypeAlphabetical}})).To(Succeed())
+	g.Expect(f.CheckCollision("1.2.3")).To(Succeed())
+}
+
+func TestRegexFilter_Select(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		label      string
+		tags       []string
+		pattern    string
+		extract    string
+		expression string
+		env        TagEnv
+		expected   []string
+		expectErr  bool
+	}{
+		{
+			label:    "without expression",
+			tags:     []string{"v1.0.0", "latest"},
+			pattern:  "^v",
+			expected: []string{"v1.0.0", "latest"},
+		},
+		{
+			label:      "with tag",
+			tags:       []string{"1.0.0", "1.0.0-debug", "1.1.0"},
+			expression: `!tag.endsWith("-debug")`,
+			expected:   []string{"1.0.0", "1.1.0"},
+		},
+		{
+			label:      "with semver fields",
+			tags:       []string{"1.0.0-rc.1", "1.1.0-rc.1", "1.2.0-rc.1", "1.2.0", "latest"},
+			expression: `has(semver.major) && semver.prerelease.startsWith("rc") && semver.minor % 2 == 0`,
+			expected:   []string{"1.0.0-rc.1", "1.2.0-rc.1"},
+		},
+		{
+			label:      "with captures and extracted value",
+			tags:       []string{"main-1-abc", "dev-2-def", "main-3-012"},
+			pattern:    `^(?P<branch>[a-z]+)-(\d+)-(?P<sha>[a-f0-9]+)$`,
+			extract:    "$2",
+			expression: `captures.branch == "main" && captures["2"] == value`,
+			expected:   []string{"main-1-abc", "main-3-012"},
+		},
+		{
+			label:      "with namespace",
+			tags:       []string{"1.0.0", "1.0.0-debug"},
+			expression: `!tag.endsWith("-debug") || policy.namespace == "dev"`,
+			env:        TagEnv{Namespace: "dev"},
+			expected:   []string{"1.0.0", "1.0.0-debug"},
+		},
+		{
+			label:      "with first seen",
+			tags:       []string{"old", "new", "unknown"},
+			expression: `firstSeen < timestamp("2026-01-01T00:00:00Z")`,
+			env: TagEnv{
+				FirstSeen: map[string]time.Time{"old": now.AddDate(-1, 0, 0), "new": now},
+				Now:       now,
+			},
+			expected: []string{"old"},
+		},
+		{
+			label:      "with evaluation error",
+			tags:       []string{"latest"},
+			expression: `semver.major > 1`,
+			expectErr:  true,
+		},
+		{
+			label:      "with cost limit exceeded",
+			tags:       []string{"latest"},
+			expression: expensiveExpression,
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			g := NewWithT(t)
+
+			f, err := NewRegexFilter(tt.pattern, tt.extract)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.expression != "" {
+				g.Expect(f.SetExpression(tt.expression)).To(Succeed())
+			}
+
+			selected, err := f.Select(context.Background(), tt.tags, tt.env)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(selected).To(Equal(tt.expected))
+		})
+	}
+}
+
+// expensiveExpression iterates a million times over the tag.
+var expensiveExpression = func() string {
+	list := "[" + strings.TrimSuffix(strings.Repeat("0,", 100), ",") + "]"
+	return fmt.Sprintf("%[1]s.all(a, %[1]s.all(b, %[1]s.all(c, tag.size() > 0)))", list)
+}()
+
+func TestNewTagExpression_cached(t *testing.T) {
+	g := NewWithT(t)
+
+	e1, err := NewTagExpression(`tag != "latest"`)
+	g.Expect(err).ToNot(HaveOccurred())
+	e2, err := NewTagExpression(`tag != "latest"`)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(e2).To(BeIdenticalTo(e1))
+
+	e3, err := NewTagExpression(`tag != "main"`)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(e3).ToNot(BeIdenticalTo(e1))
+}
+
+func TestTagExpression_Matches_interrupted(t *testing.T) {
+	g := NewWithT(t)
+
+	e, err := NewTagExpression(`[` + strings.TrimSuffix(strings.Repeat("0,", 1000), ",") + `].all(a, tag.size() > 0)`)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = e.Matches(ctx, "latest", "latest", nil, TagEnv{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("interrupted"))
+}
+
+func TestRegexFilter_ExtractValue(t *testing.T) {
+	g := NewWithT(t)
+
+	f, err := NewRegexFilter(`^v(?P<version>.*)$`, "$version")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.SetIncludeExclude(nil, []string{"-debug$"})).To(Succeed())
+
+	value, ok := f.ExtractValue("v1.2.3")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(value).To(Equal("1.2.3"))
+
+	_, ok = f.ExtractValue("1.2.3")
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = f.ExtractValue("v1.2.3-debug")
+	g.Expect(ok).To(BeFalse())
+}
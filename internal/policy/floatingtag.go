@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FloatingTag represents a policy following a single tag, e.g. stable,
+// whose image is updated in place
+type FloatingTag struct {
+	Tag string
+}
+
+// NewFloatingTag constructs a FloatingTag object validating the provided tag
+func NewFloatingTag(tag string) (*FloatingTag, error) {
+	if tag == "" {
+		return nil, fmt.Errorf("floating tag cannot be empty")
+	}
+	return &FloatingTag{
+		Tag: tag,
+	}, nil
+}
+
+// Latest returns the followed tag if it is in the provided list of strings
+func (p *FloatingTag) Latest(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("version list argument cannot be empty")
+	}
+	if !slices.Contains(versions, p.Tag) {
+		return "", fmt.Errorf("unable to determine latest version from provided list: tag '%s' not found", p.Tag)
+	}
+	return p.Tag, nil
+}
+
+// Compare only orders the followed tag, which ranks the same as itself
+func (p *FloatingTag) Compare(a, b string) (int, bool) {
+	return 0, a == p.Tag && b == p.Tag
+}
+
+// Rank returns the followed tag if it is in the provided list of strings, as
+// it is the only one ranked
+func (p *FloatingTag) Rank(versions []string) ([]string, error) {
+	latest, err := p.Latest(versions)
+	if err != nil {
+		return nil, err
+	}
+	return []string{latest}, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+)
+
+func TestNewFloatingTag(t *testing.T) {
+	if _, err := NewFloatingTag(""); err == nil {
+		t.Fatalf("expecting error, got nil")
+	}
+	if _, err := NewFloatingTag("stable"); err != nil {
+		t.Fatalf("returned unexpected error: %s", err)
+	}
+}
+
+func TestFloatingTag_Latest(t *testing.T) {
+	cases := []struct {
+		label           string
+		versions        []string
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			label:           "With tag listed",
+			versions:        []string{"1.0.0", "stable", "latest"},
+			expectedVersion: "stable",
+		},
+		{
+			label:     "With tag not listed",
+			versions:  []string{"1.0.0", "latest"},
+			expectErr: true,
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewFloatingTag("stable")
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			latest, err := policy.Latest(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if latest != tt.expectedVersion {
+				t.Errorf("incorrect computed version returned, got '%s', expected '%s'", latest, tt.expectedVersion)
+			}
+		})
+	}
+}
@@ -18,7 +18,9 @@ package policy
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
+	"regexp"
+	"sort"
 )
 
 const (
@@ -28,9 +30,16 @@ const (
 	NumericalOrderDesc = "DESC"
 )
 
+// numericRegexp matches decimal numbers with an optional sign, fraction and
+// exponent. The exponent is limited to keep the exact representation small
+var numericRegexp = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]{1,3})?$`)
+
 // Numerical representes a Numerical ordering policy
 type Numerical struct {
 	Order string
+	// SkipInvalid ignores the versions which are not numbers instead of
+	// returning an error
+	SkipInvalid bool
 }
 
 // NewNumerical constructs a Numerical object validating the provided
@@ -57,23 +66,91 @@ func (p *Numerical) Latest(versions []string) (string, error) {
 	}
 
 	var latest string
-	var pv float64
-	for i, version := range versions {
-		cv, err := strconv.ParseFloat(version, 64)
-		if err != nil {
+	var pv *big.Rat
+	for _, version := range versions {
+		cv, ok := parseNumber(version)
+		if !ok {
+			if p.SkipInvalid {
+				continue
+			}
 			return "", fmt.Errorf("failed to parse invalid numeric value '%s'", version)
 		}
 
-		switch {
-		case i == 0:
-			// First iteration, nothing to compare
-		case p.Order == NumericalOrderAsc && cv < pv, p.Order == NumericalOrderDesc && cv > pv:
-			continue
+		if pv != nil {
+			switch c := cv.Cmp(pv); {
+			case p.Order == NumericalOrderAsc && c < 0, p.Order == NumericalOrderDesc && c > 0:
+				continue
+			}
 		}
 
 		latest = version
 		pv = cv
 	}
 
+	if pv == nil {
+		return "", fmt.Errorf("unable to determine latest version from provided list")
+	}
 	return latest, nil
 }
+
+// Rank returns the provided list of strings ordered from the latest to the
+// oldest. Equal values are ranked in reverse order of appearance, as Latest
+// elects the last one
+func (p *Numerical) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	type numeric struct {
+		version string
+		value   *big.Rat
+	}
+	parsed := make([]numeric, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		cv, ok := parseNumber(versions[i])
+		if !ok {
+			if p.SkipInvalid {
+				continue
+			}
+			return nil, fmt.Errorf("failed to parse invalid numeric value '%s'", versions[i])
+		}
+		parsed = append(parsed, numeric{version: versions[i], value: cv})
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list")
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		if p.Order == NumericalOrderDesc {
+			return parsed[i].value.Cmp(parsed[j].value) < 0
+		}
+		return parsed[i].value.Cmp(parsed[j].value) > 0
+	})
+	ranked := make([]string, 0, len(parsed))
+	for _, n := range parsed {
+		ranked = append(ranked, n.version)
+	}
+	return ranked, nil
+}
+
+// Compare compares two numbers according to the configured order
+func (p *Numerical) Compare(a, b string) (int, bool) {
+	va, okA := parseNumber(a)
+	vb, okB := parseNumber(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	if p.Order == NumericalOrderDesc {
+		return vb.Cmp(va), true
+	}
+	return va.Cmp(vb), true
+}
+
+// parseNumber parses a decimal number exactly, so that integers beyond the
+// precision of a float64 are ordered correctly
+func parseNumber(s string) (*big.Rat, bool) {
+	if !numericRegexp.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
@@ -18,6 +18,7 @@ package policy
 
 import (
 	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -63,6 +64,7 @@ func TestNumerical_Latest(t *testing.T) {
 	cases := []struct {
 		label           string
 		order           string
+		skipInvalid     bool
 		versions        []string
 		expectedVersion string
 		expectErr       bool
@@ -116,6 +118,34 @@ func TestNumerical_Latest(t *testing.T) {
 			versions:  []string{"0", "1a", "b"},
 			expectErr: true,
 		},
+		{
+			label:     "With special float value",
+			versions:  []string{"0", "inf"},
+			expectErr: true,
+		},
+		{
+			label:           "With integers beyond float64 precision",
+			versions:        shuffle([]string{"1700000000000000001", "1700000000000000002", "1700000000000000000"}),
+			expectedVersion: "1700000000000000002",
+		},
+		{
+			label:           "With integers beyond float64 precision descending",
+			versions:        shuffle([]string{"9007199254740993", "9007199254740992", "9007199254740994"}),
+			order:           NumericalOrderDesc,
+			expectedVersion: "9007199254740992",
+		},
+		{
+			label:           "With skipped invalid values",
+			versions:        shuffle([]string{"1", "latest", "3", "main-2", "2"}),
+			skipInvalid:     true,
+			expectedVersion: "3",
+		},
+		{
+			label:       "With only invalid values skipped",
+			versions:    []string{"latest", "main"},
+			skipInvalid: true,
+			expectErr:   true,
+		},
 		{
 			label:     "Empty version list",
 			versions:  []string{},
@@ -129,6 +159,7 @@ func TestNumerical_Latest(t *testing.T) {
 			if err != nil {
 				t.Fatalf("returned unexpected error: %s", err)
 			}
+			policy.SkipInvalid = tt.skipInvalid
 			latest, err := policy.Latest(tt.versions)
 			if tt.expectErr && err == nil {
 				t.Fatalf("expecting error, got nil")
@@ -148,3 +179,75 @@ func shuffle(list []string) []string {
 	rand.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
 	return list
 }
+
+func TestNumerical_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		order          string
+		skipInvalid    bool
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label:          "With ascending order",
+			versions:       []string{"1", "10", "2.5", "-1"},
+			order:          NumericalOrderAsc,
+			expectedRanked: []string{"10", "2.5", "1", "-1"},
+		},
+		{
+			label:          "With descending order",
+			versions:       []string{"1", "10", "2.5", "-1"},
+			order:          NumericalOrderDesc,
+			expectedRanked: []string{"-1", "1", "2.5", "10"},
+		},
+		{
+			label:          "With integers beyond float64 precision",
+			versions:       []string{"9007199254740993", "9007199254740992", "9007199254740994"},
+			expectedRanked: []string{"9007199254740994", "9007199254740993", "9007199254740992"},
+		},
+		{
+			label:          "With skipped invalid values",
+			versions:       []string{"1", "latest", "3"},
+			skipInvalid:    true,
+			expectedRanked: []string{"3", "1"},
+		},
+		{
+			label:          "With equal values",
+			versions:       []string{"1.0", "1", "01"},
+			order:          NumericalOrderAsc,
+			expectedRanked: []string{"01", "1", "1.0"},
+		},
+		{
+			label:     "With invalid numerical value",
+			versions:  []string{"1", "a"},
+			expectErr: true,
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewNumerical(tt.order)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			policy.SkipInvalid = tt.skipInvalid
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
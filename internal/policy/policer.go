@@ -20,3 +20,25 @@ package policy
 type Policer interface {
 	Latest([]string) (string, error)
 }
+
+// Ranker is an interface representing a policy implementation type which
+// can order all the versions it considers. The first ranked version is the
+// one returned by Latest
+type Ranker interface {
+	Rank([]string) ([]string, error)
+}
+
+// Comparer is an interface representing a policy implementation type which
+// can order any two versions it can parse, regardless of its range. Compare
+// returns a negative number if a ranks lower than b, a positive one if it
+// ranks higher and zero if they rank the same, and false if either of them
+// cannot be ordered
+type Comparer interface {
+	Compare(a, b string) (int, bool)
+}
+
+// Tracker is an interface representing a policy implementation type which
+// can elect a latest version per release track
+type Tracker interface {
+	LatestByTrack([]string) (map[string]string, error)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestComparer_Compare(t *testing.T) {
+	mustPolicer := func(p Policer, err error) Policer {
+		if err != nil {
+			t.Fatalf("returned unexpected error: %s", err)
+		}
+		return p
+	}
+	creationTime := NewCreationTime()
+	creationTime.SetCreationTimes(map[string]time.Time{
+		"old": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"new": time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	multiKey := mustPolicer(NewMultiKey(regexp.MustCompile(`^build-(?P<n>[0-9]+)$`),
+		[]SortKey{{Group: "n", Type: SortKeyTypeNumeric}}))
+
+	cases := []struct {
+		label    string
+		policer  Policer
+		a, b     string
+		expected int
+		expectOk bool
+	}{
+		{
+			label:    "SemVer outside of the range",
+			policer:  mustPolicer(NewSemVer(">=1.0.0")),
+			a:        "1.5.0",
+			b:        "1.6.0-rc.1",
+			expected: -1,
+			expectOk: true,
+		},
+		{
+			label:   "SemVer invalid version",
+			policer: mustPolicer(NewSemVer(">=1.0.0")),
+			a:       "1.5.0",
+			b:       "latest",
+		},
+		{
+			label:    "Alphabetical descending",
+			policer:  mustPolicer(NewAlphabetical(AlphabeticalOrderDesc)),
+			a:        "a",
+			b:        "b",
+			expected: 1,
+			expectOk: true,
+		},
+		{
+			label:    "Numerical descending",
+			policer:  mustPolicer(NewNumerical(NumericalOrderDesc)),
+			a:        "10",
+			b:        "9",
+			expected: -1,
+			expectOk: true,
+		},
+		{
+			label:   "Numerical invalid number",
+			policer: mustPolicer(NewNumerical(NumericalOrderAsc)),
+			a:       "10",
+			b:       "ten",
+		},
+		{
+			label:    "CalVer outside of the range",
+			policer:  mustPolicer(NewCalVer("YYYY.MM.MICRO", ">=2025")),
+			a:        "2024.10.1",
+			b:        "2024.9.10",
+			expected: 1,
+			expectOk: true,
+		},
+		{
+			label:    "Version outside of the range",
+			policer:  mustPolicer(NewVersion("<10.1")),
+			a:        "10.1.2",
+			b:        "10.0.17763",
+			expected: 1,
+			expectOk: true,
+		},
+		{
+			label:    "CreationTime",
+			policer:  creationTime,
+			a:        "old",
+			b:        "new",
+			expected: -1,
+			expectOk: true,
+		},
+		{
+			label:   "CreationTime without creation time",
+			policer: creationTime,
+			a:       "old",
+			b:       "deleted",
+		},
+		{
+			label:    "FloatingTag",
+			policer:  mustPolicer(NewFloatingTag("stable")),
+			a:        "stable",
+			b:        "stable",
+			expectOk: true,
+		},
+		{
+			label:   "FloatingTag other tag",
+			policer: mustPolicer(NewFloatingTag("stable")),
+			a:       "stable",
+			b:       "1.0.0",
+		},
+		{
+			label:    "MultiKey",
+			policer:  multiKey,
+			a:        "build-10",
+			b:        "build-9",
+			expected: 1,
+			expectOk: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			comparer, ok := tt.policer.(Comparer)
+			if !ok {
+				t.Fatalf("policer %T is not a Comparer", tt.policer)
+			}
+			c, ok := comparer.Compare(tt.a, tt.b)
+			if ok != tt.expectOk {
+				t.Fatalf("expected ok %t, got %t", tt.expectOk, ok)
+			}
+			if ok && c != tt.expected {
+				t.Errorf("incorrect comparison, expected '%d', got '%d'", tt.expected, c)
+			}
+		})
+	}
+}
@@ -18,14 +18,34 @@ package policy
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/fluxcd/pkg/version"
 )
 
+const (
+	// SemVerTrackMajor groups versions by major version
+	SemVerTrackMajor = "major"
+	// SemVerTrackMinor groups versions by major and minor version
+	SemVerTrackMinor = "minor"
+)
+
 // SemVer representes a SemVer policy
 type SemVer struct {
 	Range string
+	Track string
+	// Variant is the suffix the tags must end with, separated by a dash,
+	// e.g. 'alpine' for '1.4.0-alpine'. It is stripped before parsing the
+	// version.
+	Variant string
+	// Prereleases are the prerelease channels accepted regardless of the
+	// range, e.g. 'rc' for '2.0.0-rc.3'. Prerelease versions are then
+	// checked against the range as their release version, and the
+	// prerelease versions of other channels are ignored.
+	Prereleases []string
 
 	constraint *semver.Constraints
 }
@@ -43,23 +63,160 @@ func NewSemVer(r string) (*SemVer, error) {
 	}, nil
 }
 
+// NewSemVerTracks constructs a SemVer object validating the provided semver
+// constraint, which elects a latest version per release track
+func NewSemVerTracks(r, track string) (*SemVer, error) {
+	switch track {
+	case SemVerTrackMajor, SemVerTrackMinor:
+		break
+	default:
+		return nil, fmt.Errorf("invalid track argument provided: '%s', must be one of: %s, %s", track, SemVerTrackMajor, SemVerTrackMinor)
+	}
+
+	p, err := NewSemVer(r)
+	if err != nil {
+		return nil, err
+	}
+	p.Track = track
+	return p, nil
+}
+
 // Latest returns latest version from a provided list of strings
 func (p *SemVer) Latest(versions []string) (string, error) {
 	if len(versions) == 0 {
 		return "", fmt.Errorf("version list argument cannot be empty")
 	}
 
+	var latest string
 	var latestVersion *semver.Version
 	for _, tag := range versions {
-		if v, err := version.ParseVersion(tag); err == nil {
-			if p.constraint.Check(v) && (latestVersion == nil || v.GreaterThan(latestVersion)) {
-				latestVersion = v
-			}
+		if v, ok := p.parse(tag); ok && (latestVersion == nil || v.GreaterThan(latestVersion)) {
+			latest, latestVersion = tag, v
 		}
 	}
 
 	if latestVersion != nil {
-		return latestVersion.Original(), nil
+		return latest, nil
 	}
 	return "", fmt.Errorf("unable to determine latest version from provided list")
 }
+
+// Rank returns the versions matching the constraint from a provided list of
+// strings, ordered from the latest to the oldest
+func (p *SemVer) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	type parsedTag struct {
+		tag     string
+		version *semver.Version
+	}
+	var parsed []parsedTag
+	for _, tag := range versions {
+		if v, ok := p.parse(tag); ok {
+			parsed = append(parsed, parsedTag{tag: tag, version: v})
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list")
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].version.GreaterThan(parsed[j].version)
+	})
+	ranked := make([]string, 0, len(parsed))
+	for _, t := range parsed {
+		ranked = append(ranked, t.tag)
+	}
+	return ranked, nil
+}
+
+// Compare compares two versions regardless of the range and prerelease
+// channels
+func (p *SemVer) Compare(a, b string) (int, bool) {
+	va, okA := p.version(a)
+	vb, okB := p.version(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return va.Compare(vb), true
+}
+
+// LatestByTrack returns the latest version of each release track from a
+// provided list of strings, keyed by track, e.g. '1.20' for the minor track.
+// It returns nil if no track is configured
+func (p *SemVer) LatestByTrack(versions []string) (map[string]string, error) {
+	if p.Track == "" {
+		return nil, nil
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	tracks := map[string]string{}
+	latest := map[string]*semver.Version{}
+	for _, tag := range versions {
+		v, ok := p.parse(tag)
+		if !ok {
+			continue
+		}
+		track := fmt.Sprintf("%d", v.Major())
+		if p.Track == SemVerTrackMinor {
+			track = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+		}
+		if l, ok := latest[track]; !ok || v.GreaterThan(l) {
+			tracks[track], latest[track] = tag, v
+		}
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list")
+	}
+	return tracks, nil
+}
+
+// parse parses the given tag as a version, once stripped of the variant
+// suffix, and reports whether it satisfies the policy.
+func (p *SemVer) parse(tag string) (*semver.Version, bool) {
+	v, ok := p.version(tag)
+	if !ok {
+		return nil, false
+	}
+
+	if v.Prerelease() == "" || len(p.Prereleases) == 0 {
+		return v, p.constraint.Check(v)
+	}
+	if !slices.Contains(p.Prereleases, prereleaseChannel(v.Prerelease())) {
+		return nil, false
+	}
+	release, err := v.SetPrerelease("")
+	if err != nil {
+		return nil, false
+	}
+	return v, p.constraint.Check(&release)
+}
+
+// version parses the given tag as a version, once stripped of the variant
+// suffix.
+func (p *SemVer) version(tag string) (*semver.Version, bool) {
+	s := tag
+	if p.Variant != "" {
+		var ok bool
+		if s, ok = strings.CutSuffix(tag, "-"+p.Variant); !ok {
+			return nil, false
+		}
+	}
+	v, err := version.ParseVersion(s)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// prereleaseChannel returns the channel of the given prerelease, i.e. its
+// first identifier without the trailing number, e.g. 'rc' for 'rc.3' or
+// 'rc3'.
+func prereleaseChannel(prerelease string) string {
+	first, _, _ := strings.Cut(prerelease, ".")
+	return strings.TrimRight(first, "0123456789-")
+}
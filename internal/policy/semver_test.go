@@ -17,6 +17,7 @@ limitations under the License.
 package policy
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -56,6 +57,8 @@ func TestSemVer_Latest(t *testing.T) {
 	cases := []struct {
 		label           string
 		semverRange     string
+		variant         string
+		prereleases     []string
 		versions        []string
 		expectedVersion string
 		expectErr       bool
@@ -90,6 +93,55 @@ func TestSemVer_Latest(t *testing.T) {
 			semverRange: "1.0.x",
 			expectErr:   true,
 		},
+		{
+			label:           "With variant suffix as prerelease",
+			versions:        []string{"1.3.0", "1.4.0-alpine"},
+			semverRange:     ">=1.0.0",
+			expectedVersion: "1.3.0",
+		},
+		{
+			label:           "With variant",
+			versions:        []string{"1.3.0-alpine", "1.4.0-alpine", "1.5.0", "1.6.0-debian"},
+			semverRange:     ">=1.0.0",
+			variant:         "alpine",
+			expectedVersion: "1.4.0-alpine",
+		},
+		{
+			label:           "With variant and prerelease",
+			versions:        []string{"1.4.0-alpine", "1.5.0-rc.1-alpine", "1.5.0-rc.1"},
+			semverRange:     ">=1.0.0",
+			variant:         "alpine",
+			prereleases:     []string{"rc"},
+			expectedVersion: "1.5.0-rc.1-alpine",
+		},
+		{
+			label:           "With prerelease channels",
+			versions:        []string{"1.9.0", "2.0.0-alpha.1", "2.0.0-beta2", "2.0.0-rc.3", "2.0.0-rc.10", "2.1.0-dev"},
+			semverRange:     ">=1.0.0",
+			prereleases:     []string{"rc", "beta"},
+			expectedVersion: "2.0.0-rc.10",
+		},
+		{
+			label:           "With prerelease channels and release",
+			versions:        []string{"2.0.0-rc.3", "2.0.0", "2.0.0-beta.1"},
+			semverRange:     ">=1.0.0",
+			prereleases:     []string{"rc", "beta"},
+			expectedVersion: "2.0.0",
+		},
+		{
+			label:           "With prerelease channels checked as release",
+			versions:        []string{"1.9.0", "2.0.0-rc.1"},
+			semverRange:     "<2.0.0",
+			prereleases:     []string{"rc"},
+			expectedVersion: "1.9.0",
+		},
+		{
+			label:       "With variant and no matching tag",
+			versions:    []string{"1.3.0", "1.4.0-debian"},
+			semverRange: ">=1.0.0",
+			variant:     "alpine",
+			expectErr:   true,
+		},
 	}
 
 	for _, tt := range cases {
@@ -98,6 +150,8 @@ func TestSemVer_Latest(t *testing.T) {
 			if err != nil {
 				t.Fatalf("returned unexpected error: %s", err)
 			}
+			policy.Variant = tt.variant
+			policy.Prereleases = tt.prereleases
 
 			latest, err := policy.Latest(tt.versions)
 			if tt.expectErr && err == nil {
@@ -113,3 +167,155 @@ func TestSemVer_Latest(t *testing.T) {
 		})
 	}
 }
+
+func TestSemVer_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		semverRange    string
+		variant        string
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label:          "With valid format",
+			versions:       []string{"1.0.0", "1.0.0.1", "1.0.0p", "1.0.1", "1.2.0", "0.1.0", "v1.0.2"},
+			semverRange:    "1.0.x",
+			expectedRanked: []string{"v1.0.2", "1.0.1", "1.0.0"},
+		},
+		{
+			label:          "With variant",
+			versions:       []string{"1.0.0-alpine", "1.2.0-alpine", "1.1.0-alpine", "1.3.0"},
+			semverRange:    ">=1.0.0",
+			variant:        "alpine",
+			expectedRanked: []string{"1.2.0-alpine", "1.1.0-alpine", "1.0.0-alpine"},
+		},
+		{
+			label:       "With no matching version",
+			versions:    []string{"1.2.0", "0.1.0"},
+			semverRange: "1.0.x",
+			expectErr:   true,
+		},
+		{
+			label:       "Empty version list",
+			versions:    []string{},
+			semverRange: "1.0.x",
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewSemVer(tt.semverRange)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			policy.Variant = tt.variant
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
+
+func TestNewSemVerTracks(t *testing.T) {
+	cases := []struct {
+		label     string
+		track     string
+		expectErr bool
+	}{
+		{label: "With major track", track: SemVerTrackMajor},
+		{label: "With minor track", track: SemVerTrackMinor},
+		{label: "With invalid track", track: "patch", expectErr: true},
+		{label: "With empty track", track: "", expectErr: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			_, err := NewSemVerTracks(">=1.0.0", tt.track)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSemVer_LatestByTrack(t *testing.T) {
+	versions := []string{"1.19.9", "1.20.0", "1.20.3", "v1.20.10", "1.21.1", "1.21.0", "1.22.0-rc.1", "2.0.0", "latest"}
+
+	cases := []struct {
+		label          string
+		semverRange    string
+		track          string
+		versions       []string
+		expectedTracks map[string]string
+		expectErr      bool
+	}{
+		{
+			label:          "With minor track",
+			semverRange:    ">=1.20 <2",
+			track:          SemVerTrackMinor,
+			versions:       versions,
+			expectedTracks: map[string]string{"1.20": "v1.20.10", "1.21": "1.21.1"},
+		},
+		{
+			label:          "With major track",
+			semverRange:    ">=1.0.0",
+			track:          SemVerTrackMajor,
+			versions:       versions,
+			expectedTracks: map[string]string{"1": "1.21.1", "2": "2.0.0"},
+		},
+		{
+			label:          "With prereleases in range",
+			semverRange:    ">=1.21.0-0 <2",
+			track:          SemVerTrackMinor,
+			versions:       versions,
+			expectedTracks: map[string]string{"1.21": "1.21.1", "1.22": "1.22.0-rc.1"},
+		},
+		{
+			label:          "Without track",
+			semverRange:    ">=1.0.0",
+			versions:       versions,
+			expectedTracks: nil,
+		},
+		{
+			label:       "With no matching version",
+			semverRange: ">=3.0.0",
+			track:       SemVerTrackMinor,
+			versions:    versions,
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewSemVer(tt.semverRange)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			policy.Track = tt.track
+			tracks, err := policy.LatestByTrack(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(tracks, tt.expectedTracks) {
+				t.Errorf("incorrect tracks returned, got %v, expected %v", tracks, tt.expectedTracks)
+			}
+		})
+	}
+}
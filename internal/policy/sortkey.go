@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluxcd/pkg/version"
+)
+
+const (
+	// SortKeyTypeSemVer compares values as semantic versions
+	SortKeyTypeSemVer = "semver"
+	// SortKeyTypeNumeric compares values as numbers
+	SortKeyTypeNumeric = "numeric"
+	// SortKeyTypeAlphabetical compares values as strings
+	SortKeyTypeAlphabetical = "alphabetical"
+	// SortKeyTypeTimestamp compares values as points in time
+	SortKeyTypeTimestamp = "timestamp"
+
+	// SortKeyOrderAsc ascending order
+	SortKeyOrderAsc = "ASC"
+	// SortKeyOrderDesc descending order
+	SortKeyOrderDesc = "DESC"
+)
+
+// SortKey describes how the value of a named capture group is compared
+type SortKey struct {
+	// Group is the name of the capture group
+	Group string
+	// Type is one of the SortKeyType constants
+	Type string
+	// Order is one of the SortKeyOrder constants
+	Order string
+	// Layout is the time layout used to parse timestamp values, when empty
+	// timestamps are parsed as Unix seconds
+	Layout string
+	// Priority determines the position of the key in the tuple, higher
+	// priorities are compared first
+	Priority int
+}
+
+// MultiKey represents an ordering of tags by a tuple of typed capture group
+// values
+type MultiKey struct {
+	Regexp *regexp.Regexp
+	Keys   []SortKey
+
+	groups []int
+}
+
+// NewMultiKey constructs a MultiKey object validating the provided sort keys
+// against the capture groups of the regular expression
+func NewMultiKey(m *regexp.Regexp, keys []SortKey) (*MultiKey, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("sort keys cannot be empty")
+	}
+
+	sorted := slices.Clone(keys)
+	slices.SortStableFunc(sorted, func(a, b SortKey) int { return b.Priority - a.Priority })
+
+	groups := make([]int, len(sorted))
+	for i, k := range sorted {
+		switch k.Type {
+		case SortKeyTypeSemVer, SortKeyTypeNumeric, SortKeyTypeAlphabetical, SortKeyTypeTimestamp:
+		default:
+			return nil, fmt.Errorf("invalid sort key type '%s' for group '%s', must be one of: %s, %s, %s, %s",
+				k.Type, k.Group, SortKeyTypeSemVer, SortKeyTypeNumeric, SortKeyTypeAlphabetical, SortKeyTypeTimestamp)
+		}
+		switch k.Order {
+		case "":
+			sorted[i].Order = SortKeyOrderAsc
+		case SortKeyOrderAsc, SortKeyOrderDesc:
+		default:
+			return nil, fmt.Errorf("invalid order argument provided for group '%s': '%s', must be one of: %s, %s",
+				k.Group, k.Order, SortKeyOrderAsc, SortKeyOrderDesc)
+		}
+		groups[i] = m.SubexpIndex(k.Group)
+		if k.Group == "" || groups[i] < 0 {
+			return nil, fmt.Errorf("sort key group '%s' is not a named capture group of pattern '%s'", k.Group, m.String())
+		}
+	}
+
+	return &MultiKey{
+		Regexp: m,
+		Keys:   sorted,
+		groups: groups,
+	}, nil
+}
+
+// Latest returns the tag with the highest tuple of sort key values from a
+// provided list of strings
+func (p *MultiKey) Latest(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("version list argument cannot be empty")
+	}
+
+	var latest string
+	var latestValues []string
+	for _, tag := range versions {
+		values := p.values(tag)
+		if latestValues != nil {
+			if c := p.compare(values, latestValues); c < 0 || (c == 0 && tag < latest) {
+				continue
+			}
+		}
+		latest, latestValues = tag, values
+	}
+	return latest, nil
+}
+
+// Compare compares the tuples of sort key values of two tags
+func (p *MultiKey) Compare(a, b string) (int, bool) {
+	if c := p.compare(p.values(a), p.values(b)); c != 0 {
+		return c, true
+	}
+	return strings.Compare(a, b), true
+}
+
+// values returns the values of the sort key groups for the given tag.
+func (p *MultiKey) values(tag string) []string {
+	values := make([]string, len(p.groups))
+	if m := p.Regexp.FindStringSubmatch(tag); m != nil {
+		for i, g := range p.groups {
+			values[i] = m[g]
+		}
+	}
+	return values
+}
+
+// compare compares two tuples of values key by key.
+func (p *MultiKey) compare(a, b []string) int {
+	for i, k := range p.Keys {
+		if c := k.compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compare compares two values according to the key type and order. Values
+// which are empty, i.e. the group did not match, or can not be parsed are
+// ordered before all values which can be parsed, regardless of the order.
+func (k SortKey) compare(a, b string) int {
+	var c int
+	switch k.Type {
+	case SortKeyTypeSemVer:
+		va, errA := version.ParseVersion(a)
+		vb, errB := version.ParseVersion(b)
+		if c, ok := compareParseErrors(errA, errB); ok {
+			return c
+		}
+		c = va.Compare(vb)
+	case SortKeyTypeNumeric:
+		va, okA := parseNumber(a)
+		vb, okB := parseNumber(b)
+		switch {
+		case !okA && !okB:
+			return 0
+		case !okA:
+			return -1
+		case !okB:
+			return 1
+		}
+		c = va.Cmp(vb)
+	case SortKeyTypeTimestamp:
+		va, errA := k.parseTime(a)
+		vb, errB := k.parseTime(b)
+		if c, ok := compareParseErrors(errA, errB); ok {
+			return c
+		}
+		c = va.Compare(vb)
+	default:
+		if c, ok := compareParseErrors(emptyValue(a), emptyValue(b)); ok {
+			return c
+		}
+		c = strings.Compare(a, b)
+	}
+	if k.Order == SortKeyOrderDesc {
+		return -c
+	}
+	return c
+}
+
+// emptyValue returns an error if the given value is empty, so that it is
+// ordered like a value which can not be parsed.
+func emptyValue(s string) error {
+	if s == "" {
+		return fmt.Errorf("empty value")
+	}
+	return nil
+}
+
+// parseTime parses a timestamp value using the key layout.
+func (k SortKey) parseTime(s string) (time.Time, error) {
+	if k.Layout == "" {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0), nil
+	}
+	return time.Parse(k.Layout, s)
+}
+
+// compareParseErrors orders values which failed to parse before values which
+// were parsed. It returns false if both values were parsed.
+func compareParseErrors(errA, errB error) (int, bool) {
+	switch {
+	case errA != nil && errB != nil:
+		return 0, true
+	case errA != nil:
+		return -1, true
+	case errB != nil:
+		return 1, true
+	}
+	return 0, false
+}
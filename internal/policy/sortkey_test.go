@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestNewMultiKey(t *testing.T) {
+	m := regexp.MustCompile(`^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`)
+
+	cases := []struct {
+		label     string
+		keys      []SortKey
+		expectErr bool
+	}{
+		{
+			label: "With valid keys",
+			keys: []SortKey{
+				{Group: "version", Type: SortKeyTypeSemVer},
+				{Group: "build", Type: SortKeyTypeNumeric, Order: SortKeyOrderDesc},
+			},
+		},
+		{
+			label:     "With no keys",
+			expectErr: true,
+		},
+		{
+			label:     "With unknown group",
+			keys:      []SortKey{{Group: "sha", Type: SortKeyTypeAlphabetical}},
+			expectErr: true,
+		},
+		{
+			label:     "With empty group",
+			keys:      []SortKey{{Type: SortKeyTypeAlphabetical}},
+			expectErr: true,
+		},
+		{
+			label:     "With invalid type",
+			keys:      []SortKey{{Group: "build", Type: "invalid"}},
+			expectErr: true,
+		},
+		{
+			label:     "With invalid order",
+			keys:      []SortKey{{Group: "build", Type: SortKeyTypeNumeric, Order: "invalid"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			_, err := NewMultiKey(m, tt.keys)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestMultiKey_Latest(t *testing.T) {
+	cases := []struct {
+		label           string
+		pattern         string
+		keys            []SortKey
+		versions        []string
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			label:   "With semver then build number",
+			pattern: `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+			keys: []SortKey{
+				{Group: "version", Type: SortKeyTypeSemVer},
+				{Group: "build", Type: SortKeyTypeNumeric},
+			},
+			versions:        []string{"1.4.2-build.37", "1.4.2-build.9", "1.4.10-build.1", "1.3.0-build.99"},
+			expectedVersion: "1.4.10-build.1",
+		},
+		{
+			label:   "With priorities",
+			pattern: `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+			keys: []SortKey{
+				{Group: "version", Type: SortKeyTypeSemVer},
+				{Group: "build", Type: SortKeyTypeNumeric, Priority: 1},
+			},
+			versions:        []string{"1.4.2-build.37", "1.4.2-build.9", "1.4.10-build.1", "1.3.0-build.99"},
+			expectedVersion: "1.3.0-build.99",
+		},
+		{
+			label:   "With descending order",
+			pattern: `^(?P<version>[0-9.]+)-build\.(?P<build>[0-9]+)$`,
+			keys: []SortKey{
+				{Group: "version", Type: SortKeyTypeSemVer},
+				{Group: "build", Type: SortKeyTypeNumeric, Order: SortKeyOrderDesc},
+			},
+			versions:        []string{"1.4.2-build.37", "1.4.2-build.9", "1.4.1-build.1"},
+			expectedVersion: "1.4.2-build.9",
+		},
+		{
+			label:   "With build numbers beyond float64 precision",
+			pattern: `^build-(?P<build>[0-9]+)$`,
+			keys: []SortKey{
+				{Group: "build", Type: SortKeyTypeNumeric},
+			},
+			versions:        []string{"build-9007199254740993", "build-9007199254740992"},
+			expectedVersion: "build-9007199254740993",
+		},
+		{
+			label:   "With unix timestamps",
+			pattern: `^main-(?P<ts>[0-9]+)-(?P<sha>[a-f0-9]+)$`,
+			keys: []SortKey{
+				{Group: "ts", Type: SortKeyTypeTimestamp},
+			},
+			versions:        []string{"main-1712345678-abc123", "main-1712345999-0ff1ce", "main-999-fff"},
+			expectedVersion: "main-1712345999-0ff1ce",
+		},
+		{
+			label:   "With timestamp layout",
+			pattern: `^(?P<ts>.+)$`,
+			keys: []SortKey{
+				{Group: "ts", Type: SortKeyTypeTimestamp, Layout: "2006-01-02T15-04-05Z"},
+			},
+			versions:        []string{"2021-01-08T21-18-21Z", "2023-05-08T00-20-00Z", "1990-01-08T00-20-00Z"},
+			expectedVersion: "2023-05-08T00-20-00Z",
+		},
+		{
+			label:   "With alphabetical key and unparsable values",
+			pattern: `^(?P<name>[a-z]+)-(?P<num>.+)$`,
+			keys: []SortKey{
+				{Group: "num", Type: SortKeyTypeNumeric, Priority: 1},
+				{Group: "name", Type: SortKeyTypeAlphabetical},
+			},
+			versions:        []string{"zzz-x", "aaa-1", "bbb-1"},
+			expectedVersion: "bbb-1",
+		},
+		{
+			label:   "With descending order and unmatched group",
+			pattern: `^v(?P<version>[0-9]+)(-b(?P<build>[0-9]+))?$`,
+			keys: []SortKey{
+				{Group: "build", Type: SortKeyTypeNumeric, Order: SortKeyOrderDesc},
+			},
+			versions:        []string{"v1-b3", "v1-b5", "v1"},
+			expectedVersion: "v1-b3",
+		},
+		{
+			label:   "With descending order and unparsable values",
+			pattern: `^(?P<ts>.+)$`,
+			keys: []SortKey{
+				{Group: "ts", Type: SortKeyTypeTimestamp, Layout: "2006-01-02", Order: SortKeyOrderDesc},
+			},
+			versions:        []string{"2023-05-08", "latest", "2021-01-08"},
+			expectedVersion: "2021-01-08",
+		},
+		{
+			label:   "With descending alphabetical order and unmatched group",
+			pattern: `^(?P<name>[a-z]+)?-(?P<num>[0-9]+)$`,
+			keys: []SortKey{
+				{Group: "name", Type: SortKeyTypeAlphabetical, Order: SortKeyOrderDesc},
+			},
+			versions:        []string{"-1", "bbb-1", "aaa-1"},
+			expectedVersion: "aaa-1",
+		},
+		{
+			label:     "Empty version list",
+			pattern:   `^(?P<name>.+)$`,
+			keys:      []SortKey{{Group: "name", Type: SortKeyTypeAlphabetical}},
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewMultiKey(regexp.MustCompile(tt.pattern), tt.keys)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			latest, err := policy.Latest(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if latest != tt.expectedVersion {
+				t.Errorf("incorrect computed version returned, got '%s', expected '%s'", latest, tt.expectedVersion)
+			}
+		})
+	}
+}
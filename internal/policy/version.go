@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// versionConstraintPattern matches a single comparison constraint such as
+// '>=10.0.17763'.
+var versionConstraintPattern = regexp.MustCompile(`^(>=|<=|!=|>|<|=)?v?([0-9]+(?:\.[0-9]+)*)$`)
+
+// Version represents a policy ordering dotted versions with any number of
+// numeric components, e.g. 10.0.17763.5122
+type Version struct {
+	Range string
+
+	constraints []versionConstraint
+}
+
+type versionConstraint struct {
+	op      string
+	version []uint64
+}
+
+// NewVersion constructs a Version object validating the provided range
+// argument
+func NewVersion(r string) (*Version, error) {
+	p := &Version{
+		Range: r,
+	}
+	if err := p.parseRange(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Latest returns the highest version matching the range from a provided list
+// of strings
+func (p *Version) Latest(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", fmt.Errorf("version list argument cannot be empty")
+	}
+
+	var latest string
+	var latestVersion []uint64
+	for _, tag := range versions {
+		v, ok := parseDottedVersion(tag)
+		if !ok || !p.check(v) {
+			continue
+		}
+		if latestVersion == nil || compareDottedVersions(v, latestVersion, tag, latest) > 0 {
+			latest, latestVersion = tag, v
+		}
+	}
+
+	if latestVersion != nil {
+		return latest, nil
+	}
+	return "", fmt.Errorf("unable to determine latest version from provided list")
+}
+
+// Rank returns the versions matching the range from a provided list of
+// strings, ordered from the highest to the lowest
+func (p *Version) Rank(versions []string) ([]string, error) {
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version list argument cannot be empty")
+	}
+
+	parsed := map[string][]uint64{}
+	var ranked []string
+	for _, tag := range versions {
+		if v, ok := parseDottedVersion(tag); ok && p.check(v) {
+			parsed[tag] = v
+			ranked = append(ranked, tag)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("unable to determine latest version from provided list")
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return compareDottedVersions(parsed[ranked[i]], parsed[ranked[j]], ranked[i], ranked[j]) > 0
+	})
+	return ranked, nil
+}
+
+// Compare compares two dotted versions regardless of the range
+func (p *Version) Compare(a, b string) (int, bool) {
+	va, okA := parseDottedVersion(a)
+	vb, okB := parseDottedVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return compareDottedVersions(va, vb, a, b), true
+}
+
+// parseRange parses the comma or whitespace separated list of constraints.
+func (p *Version) parseRange() error {
+	for _, field := range splitConstraints(p.Range) {
+		m := versionConstraintPattern.FindStringSubmatch(field)
+		if m == nil {
+			return fmt.Errorf("invalid version range '%s': cannot parse constraint '%s'", p.Range, field)
+		}
+		op := m[1]
+		if op == "" {
+			op = "="
+		}
+		version, ok := parseDottedVersion(m[2])
+		if !ok {
+			return fmt.Errorf("invalid version range '%s': cannot parse constraint '%s'", p.Range, field)
+		}
+		p.constraints = append(p.constraints, versionConstraint{op: op, version: version})
+	}
+	return nil
+}
+
+// check reports whether the given version satisfies all the constraints.
+// Only the leading components of the version are compared, as many as the
+// constraint has, so that '<10.1' excludes 10.1.2 and '=10.0' matches 10.0.3.
+func (p *Version) check(v []uint64) bool {
+	for _, c := range p.constraints {
+		prefix := v
+		if len(prefix) > len(c.version) {
+			prefix = prefix[:len(c.version)]
+		}
+		r := compareComponents(prefix, c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = r == 0
+		case "!=":
+			ok = r != 0
+		case ">":
+			ok = r > 0
+		case ">=":
+			ok = r >= 0
+		case "<":
+			ok = r < 0
+		case "<=":
+			ok = r <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDottedVersion parses a version made of numeric components separated
+// by dots, with an optional 'v' prefix.
+func parseDottedVersion(s string) ([]uint64, bool) {
+	components := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	v := make([]uint64, 0, len(components))
+	for _, component := range components {
+		if component == "" || strings.TrimLeft(component, "0123456789") != "" {
+			return nil, false
+		}
+		n, err := strconv.ParseUint(component, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		v = append(v, n)
+	}
+	return v, true
+}
+
+// compareDottedVersions compares the versions a and b, parsed from the tags
+// ta and tb. Equal versions, e.g. 1.2 and 1.2.0, are ordered by tag.
+func compareDottedVersions(a, b []uint64, ta, tb string) int {
+	if r := compareComponents(a, b); r != 0 {
+		return r
+	}
+	return strings.Compare(ta, tb)
+}
+
+// compareComponents compares the components of a and b one by one, the
+// missing components being considered as zero.
+func compareComponents(a, b []uint64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var ca, cb uint64
+		if i < len(a) {
+			ca = a[i]
+		}
+		if i < len(b) {
+			cb = b[i]
+		}
+		if r := cmp.Compare(ca, cb); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
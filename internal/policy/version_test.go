@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewVersion(t *testing.T) {
+	cases := []struct {
+		label      string
+		versionRng string
+		expectErr  bool
+	}{
+		{
+			label: "With empty range",
+		},
+		{
+			label:      "With valid range",
+			versionRng: ">=10.0.17763, <10.0.20348",
+		},
+		{
+			label:      "With space separated range",
+			versionRng: ">= 1.2.3.4 < 2 != 1.5",
+		},
+		{
+			label:      "With prefixed version",
+			versionRng: ">=v1.2",
+		},
+		{
+			label:      "With invalid operator",
+			versionRng: "~1.2.3.4",
+			expectErr:  true,
+		},
+		{
+			label:      "With invalid version",
+			versionRng: ">=1.2.x",
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			_, err := NewVersion(tt.versionRng)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestVersion_Latest(t *testing.T) {
+	cases := []struct {
+		label           string
+		versionRng      string
+		versions        []string
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			label:           "With four components",
+			versions:        []string{"1.2.3.4", "1.2.3.10", "1.2.3.9", "1.2.2.99"},
+			expectedVersion: "1.2.3.10",
+		},
+		{
+			label:           "With Windows builds",
+			versions:        []string{"10.0.17763.5122", "10.0.17763.6054", "10.0.20348.2113", "latest", "ltsc2019"},
+			versionRng:      "<10.0.20348",
+			expectedVersion: "10.0.17763.6054",
+		},
+		{
+			label:           "With prefix constraint",
+			versions:        []string{"10.0.17763.5122", "10.0.17763.6054", "10.0.20348.2113"},
+			versionRng:      "=10.0.20348",
+			expectedVersion: "10.0.20348.2113",
+		},
+		{
+			label:           "With greater than prefix constraint",
+			versions:        []string{"10.0.17763.5122", "10.0.20348.2113", "10.0.17764"},
+			versionRng:      ">10.0.17763, <10.0.20348",
+			expectedVersion: "10.0.17764",
+		},
+		{
+			label:           "With mixed number of components",
+			versions:        []string{"1.2", "1.2.0.1", "1.1.9.9.9", "v1.3"},
+			expectedVersion: "v1.3",
+		},
+		{
+			label:           "With equal versions",
+			versions:        []string{"1.2.0", "1.2"},
+			expectedVersion: "1.2.0",
+		},
+		{
+			label:           "With suffixes",
+			versions:        []string{"1.2.3.4-rc1", "1.2.3.4a", "1.2.3.3", "1..2"},
+			expectedVersion: "1.2.3.3",
+		},
+		{
+			label:      "With non-matching version list",
+			versions:   []string{"1.2.3.4"},
+			versionRng: ">=2",
+			expectErr:  true,
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewVersion(tt.versionRng)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			latest, err := policy.Latest(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if latest != tt.expectedVersion {
+				t.Errorf("incorrect computed version returned, got '%s', expected '%s'", latest, tt.expectedVersion)
+			}
+		})
+	}
+}
+
+func TestVersion_Rank(t *testing.T) {
+	cases := []struct {
+		label          string
+		versionRng     string
+		versions       []string
+		expectedRanked []string
+		expectErr      bool
+	}{
+		{
+			label:          "With range",
+			versions:       []string{"1.2.3.4", "1.2.3.10", "2.0.0.0", "1.2.3.9", "latest"},
+			versionRng:     "<2",
+			expectedRanked: []string{"1.2.3.10", "1.2.3.9", "1.2.3.4"},
+		},
+		{
+			label:     "With no matching version",
+			versions:  []string{"latest"},
+			expectErr: true,
+		},
+		{
+			label:     "Empty version list",
+			versions:  []string{},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.label, func(t *testing.T) {
+			policy, err := NewVersion(tt.versionRng)
+			if err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+			ranked, err := policy.Rank(tt.versions)
+			if tt.expectErr && err == nil {
+				t.Fatalf("expecting error, got nil")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("returned unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(ranked, tt.expectedRanked) {
+				t.Errorf("incorrect ranked versions returned, got %v, expected %v", ranked, tt.expectedRanked)
+			}
+		})
+	}
+}
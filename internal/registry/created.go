@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// ImageCreatedAnnotation is the OCI annotation holding the date and time on
+// which the image was built.
+const ImageCreatedAnnotation = "org.opencontainers.image.created"
+
+// ImageCreated returns the creation time of the image described by the given
+// descriptor. It prefers the org.opencontainers.image.created annotation of
+// the manifest, then the label of the same name in the image config, and
+// finally falls back to the created field of the image config. For image
+// indexes, the config of the image matching the platform configured in the
+// descriptor is used. A zero time is returned if none of them is set.
+func ImageCreated(desc *remote.Descriptor) (time.Time, error) {
+	var annotations map[string]string
+	if desc.MediaType.IsIndex() {
+		manifest, err := v1.ParseIndexManifest(bytes.NewReader(desc.Manifest))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse image index manifest: %w", err)
+		}
+		annotations = manifest.Annotations
+	} else {
+		manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse image manifest: %w", err)
+		}
+		annotations = manifest.Annotations
+	}
+	if created, ok, err := parseCreated(annotations); ok || err != nil {
+		return created, err
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read image config: %w", err)
+	}
+	if created, ok, err := parseCreated(config.Config.Labels); ok || err != nil {
+		return created, err
+	}
+	return config.Created.Time, nil
+}
+
+// parseCreated parses the creation time annotation from the given map.
+func parseCreated(m map[string]string) (time.Time, bool, error) {
+	v, ok := m[ImageCreatedAnnotation]
+	if !ok || v == "" {
+		return time.Time{}, false, nil
+	}
+	created, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s value '%s': %w", ImageCreatedAnnotation, v, err)
+	}
+	return created, true, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/gomega"
+
+	"github.com/fluxcd/image-reflector-controller/internal/registry"
+	"github.com/fluxcd/image-reflector-controller/internal/test"
+)
+
+func TestImageCreated(t *testing.T) {
+	configCreated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	annotationCreated := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mutate  func(img v1.Image) (v1.Image, error)
+		index   bool
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "config created",
+			mutate: func(img v1.Image) (v1.Image, error) {
+				return mutate.CreatedAt(img, v1.Time{Time: configCreated})
+			},
+			want: configCreated,
+		},
+		{
+			name: "config label",
+			mutate: func(img v1.Image) (v1.Image, error) {
+				img, err := mutate.CreatedAt(img, v1.Time{Time: configCreated})
+				if err != nil {
+					return nil, err
+				}
+				cfg, err := img.ConfigFile()
+				if err != nil {
+					return nil, err
+				}
+				cfg = cfg.DeepCopy()
+				cfg.Config.Labels = map[string]string{registry.ImageCreatedAnnotation: annotationCreated.Format(time.RFC3339)}
+				return mutate.ConfigFile(img, cfg)
+			},
+			want: annotationCreated,
+		},
+		{
+			name: "manifest annotation",
+			mutate: func(img v1.Image) (v1.Image, error) {
+				img, err := mutate.CreatedAt(img, v1.Time{Time: configCreated})
+				if err != nil {
+					return nil, err
+				}
+				return mutate.Annotations(img, map[string]string{
+					registry.ImageCreatedAnnotation: annotationCreated.Format(time.RFC3339),
+				}).(v1.Image), nil
+			},
+			want: annotationCreated,
+		},
+		{
+			name: "invalid annotation",
+			mutate: func(img v1.Image) (v1.Image, error) {
+				return mutate.Annotations(img, map[string]string{
+					registry.ImageCreatedAnnotation: "yesterday",
+				}).(v1.Image), nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "image index",
+			mutate: func(img v1.Image) (v1.Image, error) {
+				return mutate.CreatedAt(img, v1.Time{Time: configCreated})
+			},
+			index: true,
+			want:  configCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			registryServer := test.NewRegistryServer()
+			defer registryServer.Close()
+
+			img, err := random.Image(512, 1)
+			g.Expect(err).ToNot(HaveOccurred())
+			img, err = tt.mutate(img)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ref, err := name.ParseReference(test.RegistryName(registryServer) + "/foo/bar:v1")
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.index {
+				base, err := random.Index(0, 0, 0)
+				g.Expect(err).ToNot(HaveOccurred())
+				idx := mutate.AppendManifests(base, mutate.IndexAddendum{
+					Add: img,
+					Descriptor: v1.Descriptor{
+						Platform: &v1.Platform{OS: "linux", Architecture: "amd64"},
+					},
+				})
+				g.Expect(remote.WriteIndex(ref, idx)).To(Succeed())
+			} else {
+				g.Expect(remote.Write(ref, img)).To(Succeed())
+			}
+
+			desc, err := remote.Get(ref)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			created, err := registry.ImageCreated(desc)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(created.Equal(tt.want)).To(BeTrue(), "got %s, want %s", created, tt.want)
+		})
+	}
+}
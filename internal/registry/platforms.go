@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// PlatformManifest is the manifest of a single platform of an image index.
+type PlatformManifest struct {
+	// Platform is the platform in the os/arch[/variant] format.
+	Platform string
+	// Digest is the digest of the manifest.
+	Digest string
+}
+
+// PlatformManifests returns the platform manifests of the image index
+// described by the given descriptor, in the order they are listed in the
+// index. Manifests without a platform, or with the unknown/unknown one used
+// for attestations, are skipped. Nil is returned if the descriptor is not an
+// image index.
+func PlatformManifests(desc *remote.Descriptor) ([]PlatformManifest, error) {
+	if !desc.MediaType.IsIndex() {
+		return nil, nil
+	}
+	index, err := v1.ParseIndexManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image index manifest: %w", err)
+	}
+
+	var manifests []PlatformManifest
+	for _, m := range index.Manifests {
+		if m.Platform == nil || (m.Platform.OS == "unknown" && m.Platform.Architecture == "unknown") {
+			continue
+		}
+		platform := m.Platform.OS + "/" + m.Platform.Architecture
+		if m.Platform.Variant != "" {
+			platform += "/" + m.Platform.Variant
+		}
+		manifests = append(manifests, PlatformManifest{Platform: platform, Digest: m.Digest.String()})
+	}
+	return manifests, nil
+}
+
+// SelectPlatform returns the index of the first of the given platforms, in
+// the os/arch[/variant] format, matching the wanted one, or -1 if none does.
+// Like containerd, the architectures and their default variants are
+// normalized, e.g. linux/arm64 matches linux/arm64/v8, and a wanted platform
+// without variant matches any variant, unless one matches exactly.
+func SelectPlatform(want string, platforms []string) int {
+	wantOS, wantArch, wantVariant := normalizePlatform(want)
+	match := -1
+	for i, platform := range platforms {
+		os, arch, variant := normalizePlatform(platform)
+		if os != wantOS || arch != wantArch {
+			continue
+		}
+		if variant == wantVariant {
+			return i
+		}
+		if wantVariant == "" && match < 0 {
+			match = i
+		}
+	}
+	return match
+}
+
+// normalizePlatform splits the given platform into its os, architecture and
+// variant, normalizing the architecture and dropping its default variant.
+func normalizePlatform(platform string) (os, arch, variant string) {
+	os, rest, _ := strings.Cut(strings.ToLower(platform), "/")
+	arch, variant, _ = strings.Cut(rest, "/")
+	switch arch {
+	case "x86_64", "x86-64", "amd64":
+		arch = "amd64"
+		if variant == "v1" {
+			variant = ""
+		}
+	case "aarch64", "arm64":
+		arch = "arm64"
+		if variant == "8" || variant == "v8" {
+			variant = ""
+		}
+	case "armhf":
+		arch, variant = "arm", "v7"
+	case "armel":
+		arch, variant = "arm", "v6"
+	case "i386":
+		arch = "386"
+	}
+	if arch == "arm" && len(variant) == 1 {
+		variant = "v" + variant
+	}
+	return os, arch, variant
+}
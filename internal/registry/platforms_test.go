@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/gomega"
+
+	"github.com/fluxcd/image-reflector-controller/internal/registry"
+	"github.com/fluxcd/image-reflector-controller/internal/test"
+)
+
+func TestPlatformManifests(t *testing.T) {
+	g := NewWithT(t)
+
+	registryServer := test.NewRegistryServer()
+	defer registryServer.Close()
+
+	platforms := []*v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+		{OS: "unknown", Architecture: "unknown"},
+		nil,
+	}
+	var want []registry.PlatformManifest
+	idx, err := random.Index(0, 0, 0)
+	g.Expect(err).ToNot(HaveOccurred())
+	for i, platform := range platforms {
+		img, err := random.Image(512, 1)
+		g.Expect(err).ToNot(HaveOccurred())
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: platform},
+		})
+		if i < 2 {
+			digest, err := img.Digest()
+			g.Expect(err).ToNot(HaveOccurred())
+			want = append(want, registry.PlatformManifest{Digest: digest.String()})
+		}
+	}
+	want[0].Platform = "linux/amd64"
+	want[1].Platform = "linux/arm/v7"
+
+	indexRef, err := name.ParseReference(test.RegistryName(registryServer) + "/foo/bar:v1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.WriteIndex(indexRef, idx)).To(Succeed())
+	desc, err := remote.Get(indexRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	manifests, err := registry.PlatformManifests(desc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(manifests).To(Equal(want))
+
+	img, err := random.Image(512, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	imageRef, err := name.ParseReference(test.RegistryName(registryServer) + "/foo/bar:v2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(remote.Write(imageRef, img)).To(Succeed())
+	desc, err = remote.Get(imageRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	manifests, err = registry.PlatformManifests(desc)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(manifests).To(BeNil())
+}
+
+func TestSelectPlatform(t *testing.T) {
+	platforms := []string{"linux/amd64", "linux/arm/v6", "linux/arm/v7", "linux/arm64/v8", "windows/amd64"}
+
+	tests := []struct {
+		want     string
+		expected int
+	}{
+		{want: "linux/amd64", expected: 0},
+		{want: "linux/amd64/v1", expected: 0},
+		{want: "linux/arm64", expected: 3},
+		{want: "linux/arm64/v8", expected: 3},
+		{want: "linux/arm", expected: 1},
+		{want: "linux/arm/v7", expected: 2},
+		{want: "linux/arm/7", expected: 2},
+		{want: "linux/arm/v5", expected: -1},
+		{want: "linux/386", expected: -1},
+		{want: "windows/amd64", expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(registry.SelectPlatform(tt.want, platforms)).To(Equal(tt.expected))
+		})
+	}
+
+	g := NewWithT(t)
+	g.Expect(registry.SelectPlatform("linux/arm64/v8", []string{"linux/arm64"})).To(Equal(0))
+	g.Expect(registry.SelectPlatform("linux/arm", []string{"linux/arm/v6", "linux/arm"})).To(Equal(1))
+}
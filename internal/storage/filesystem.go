@@ -21,10 +21,12 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/fluxcd/pkg/apis/meta"
 	artifactstorage "github.com/fluxcd/pkg/artifact/storage"
@@ -35,12 +37,14 @@ import (
 const (
 	tagsFilePlain = "tags.txt"
 	tagsFileGzip  = "tags.txt.gz"
+	metadataFile  = "metadata.json"
 )
 
 // FilesystemDatabase stores image tags per ImageRepository on the local filesystem.
 type FilesystemDatabase struct {
 	storage              *artifactstorage.Storage
 	compressionThreshold int
+	history              *TagHistory
 }
 
 // NewFilesystemDatabase creates a filesystem-backed tag database.
@@ -48,6 +52,7 @@ func NewFilesystemDatabase(storage *artifactstorage.Storage, compressionThreshol
 	return &FilesystemDatabase{
 		storage:              storage,
 		compressionThreshold: compressionThreshold,
+		history:              NewTagHistory(storage.BasePath),
 	}
 }
 
@@ -92,6 +97,7 @@ func (d *FilesystemDatabase) Tags(ctx context.Context, repo RepoIdentity) ([]str
 }
 
 // SetTags implements the DatabaseWriter interface, recording tags for the repo.
+// The image metadata of the repo is reset.
 func (d *FilesystemDatabase) SetTags(ctx context.Context, repo RepoIdentity, tags []string) (string, error) {
 	if err := ctx.Err(); err != nil {
 		return "", err
@@ -132,9 +138,25 @@ func (d *FilesystemDatabase) SetTags(ctx context.Context, repo RepoIdentity, tag
 		removeStaleVariant(tagFileVariant{path: d.storage.LocalPath(artifactForRepo(repo, tagsFilePlain))})
 	}
 
+	if err := d.history.Record(repo, tags, time.Now()); err != nil {
+		return "", err
+	}
+	if err := d.updateMetadata(repo, (*RepoMetadata).Reset); err != nil {
+		return "", err
+	}
+
 	return revision, nil
 }
 
+// FirstSeen implements the DatabaseReader interface, fetching the time each
+// tag of the repo was first observed.
+func (d *FilesystemDatabase) FirstSeen(ctx context.Context, repo RepoIdentity) (map[string]time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.history.FirstSeen(repo)
+}
+
 // Delete implements the DatabaseWriter interface, deleting tags for the repo.
 func (d *FilesystemDatabase) Delete(ctx context.Context, repo RepoIdentity) error {
 	if err := ctx.Err(); err != nil {
@@ -147,9 +169,81 @@ func (d *FilesystemDatabase) Delete(ctx context.Context, repo RepoIdentity) erro
 	if err != nil {
 		return fmt.Errorf("failed to delete tags: %w", err)
 	}
+	return d.history.Delete(repo)
+}
+
+// Metadata implements the MetadataCache interface, fetching the image
+// metadata recorded for the repo.
+func (d *FilesystemDatabase) Metadata(ctx context.Context, repo RepoIdentity) (RepoMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return RepoMetadata{}, err
+	}
+	if err := validateRepoIdentity(repo); err != nil {
+		return RepoMetadata{}, err
+	}
+	return d.readMetadata(repo)
+}
+
+// SetMetadata implements the MetadataCache interface, merging the given image
+// metadata into the one recorded for the repo.
+func (d *FilesystemDatabase) SetMetadata(ctx context.Context, repo RepoIdentity, metadata RepoMetadata) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := validateRepoIdentity(repo); err != nil {
+		return err
+	}
+	return d.updateMetadata(repo, func(all *RepoMetadata) bool {
+		all.Merge(metadata)
+		return true
+	})
+}
+
+// updateMetadata applies the given update to the image metadata of the repo
+// while holding its lock, and writes it if the update reports a change.
+func (d *FilesystemDatabase) updateMetadata(repo RepoIdentity, update func(*RepoMetadata) bool) error {
+	artifact := artifactForRepo(repo, metadataFile)
+	if err := d.storage.MkdirAll(artifact); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	unlock, err := d.storage.Lock(artifact)
+	if err != nil {
+		return fmt.Errorf("failed to lock metadata: %w", err)
+	}
+	defer unlock()
+
+	metadata, err := d.readMetadata(repo)
+	if err != nil {
+		return err
+	}
+	if !update(&metadata) {
+		return nil
+	}
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := d.storage.AtomicWriteFile(&artifact, bytes.NewReader(b), 0o600); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
 	return nil
 }
 
+func (d *FilesystemDatabase) readMetadata(repo RepoIdentity) (RepoMetadata, error) {
+	var metadata RepoMetadata
+	b, err := os.ReadFile(d.storage.LocalPath(artifactForRepo(repo, metadataFile)))
+	if errors.Is(err, os.ErrNotExist) {
+		return metadata, nil
+	}
+	if err != nil {
+		return metadata, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	if err := json.Unmarshal(b, &metadata); err != nil {
+		return metadata, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return metadata, nil
+}
+
 func validateRepoIdentity(repo RepoIdentity) error {
 	if repo.Namespace == "" || repo.Name == "" {
 		return fmt.Errorf("repo namespace and name are required")
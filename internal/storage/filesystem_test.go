@@ -229,6 +229,129 @@ func TestFilesystemDatabaseDelete(t *testing.T) {
 	}
 }
 
+func TestFilesystemDatabaseFirstSeen(t *testing.T) {
+	db, _ := newFilesystemDatabase(t, 1024)
+	repo := testRepoIdentity("default", "podinfo")
+
+	before := time.Now()
+	if _, err := db.SetTags(context.Background(), repo, []string{"v1.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	firstSeen, err := db.FirstSeen(context.Background(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := firstSeen["v1.0.0"]
+	if seen.Before(before.Truncate(time.Second)) || seen.After(time.Now()) {
+		t.Fatalf("FirstSeen() got %s, want time of SetTags", seen)
+	}
+
+	if _, err := db.SetTags(context.Background(), repo, []string{"v1.0.0", "v1.1.0"}); err != nil {
+		t.Fatal(err)
+	}
+	firstSeen, err = db.FirstSeen(context.Background(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firstSeen) != 2 || !firstSeen["v1.0.0"].Equal(seen) {
+		t.Fatalf("FirstSeen() got %#v, want v1.0.0 first seen at %s", firstSeen, seen)
+	}
+
+	if err := db.Delete(context.Background(), repo); err != nil {
+		t.Fatal(err)
+	}
+	firstSeen, err = db.FirstSeen(context.Background(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firstSeen) != 0 {
+		t.Fatalf("FirstSeen() after delete got %#v, want empty", firstSeen)
+	}
+}
+
+func TestFilesystemDatabaseMetadata(t *testing.T) {
+	db, _ := newFilesystemDatabase(t, 1024)
+	repo := testRepoIdentity("default", "podinfo")
+	ctx := context.Background()
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	metadata, err := db.Metadata(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(metadata, RepoMetadata{}) {
+		t.Fatalf("Metadata() for unknown repo got %#v, want empty", metadata)
+	}
+
+	if _, err := db.SetTags(ctx, repo, []string{"latest", "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetMetadata(ctx, repo, RepoMetadata{
+		TagDigests: map[string]string{"latest": "sha256:aaa"},
+		Images:     map[string]ImageMetadata{"sha256:aaa": {Created: created}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetMetadata(ctx, repo, RepoMetadata{
+		TagDigests: map[string]string{"v1": "sha256:bbb"},
+		Images:     map[string]ImageMetadata{"sha256:bbb": {Created: created.Add(time.Hour)}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err = db.Metadata(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RepoMetadata{
+		TagDigests: map[string]string{"latest": "sha256:aaa", "v1": "sha256:bbb"},
+		Images: map[string]ImageMetadata{
+			"sha256:aaa": {Created: created},
+			"sha256:bbb": {Created: created.Add(time.Hour)},
+		},
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() got %#v, want %#v", metadata, want)
+	}
+
+	// Recording the tags forgets the tag digests, but keeps the metadata of
+	// the images they pointed to until the tags are recorded again.
+	if _, err := db.SetTags(ctx, repo, []string{"v1", "v2"}); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err = db.Metadata(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = RepoMetadata{Images: want.Images}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() after SetTags got %#v, want %#v", metadata, want)
+	}
+
+	if err := db.SetMetadata(ctx, repo, RepoMetadata{TagDigests: map[string]string{"v1": "sha256:bbb"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.SetTags(ctx, repo, []string{"v1", "v2"}); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err = db.Metadata(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = RepoMetadata{Images: map[string]ImageMetadata{"sha256:bbb": {Created: created.Add(time.Hour)}}}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Fatalf("Metadata() after pruning got %#v, want %#v", metadata, want)
+	}
+
+	other, err := db.Metadata(ctx, testRepoIdentity("other", "podinfo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(other, RepoMetadata{}) {
+		t.Fatalf("Metadata() for other repo got %#v, want empty", other)
+	}
+}
+
 func newFilesystemDatabase(t *testing.T, threshold int) (*FilesystemDatabase, *artifactstorage.Storage) {
 	t.Helper()
 	st := &artifactstorage.Storage{BasePath: t.TempDir()}
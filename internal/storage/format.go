@@ -31,7 +31,7 @@ const (
 
 // ReconcileFormat prepares the storage root for the selected storage backend.
 // Switching backends wipes the rebuildable tag cache before either backend is
-// initialized.
+// initialized. The tag history is kept, as it can't be rebuilt.
 func ReconcileFormat(storagePath string, filesystemStorageEnabled bool) error {
 	if err := os.MkdirAll(storagePath, 0o700); err != nil {
 		return fmt.Errorf("failed to create storage path: %w", err)
@@ -162,7 +162,7 @@ func wipeStoragePath(storagePath string) error {
 	}
 	for _, entry := range entries {
 		name := entry.Name()
-		if name == storageVersionFile || name == storageWipeInProgressFile {
+		if name == storageVersionFile || name == storageWipeInProgressFile || name == tagHistoryDir {
 			continue
 		}
 		if err := os.RemoveAll(filepath.Join(storagePath, name)); err != nil {
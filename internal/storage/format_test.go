@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 )
 
 func TestReconcileFormat(t *testing.T) {
@@ -118,6 +119,36 @@ func TestReconcileFormatCompletesInterruptedWipe(t *testing.T) {
 	}
 }
 
+func TestReconcileFormatKeepsTagHistory(t *testing.T) {
+	dir := t.TempDir()
+	repo := RepoIdentity{Namespace: "default", Name: "podinfo"}
+	firstSeen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := NewTagHistory(dir).Record(repo, []string{"v1.0.0"}, firstSeen); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data"), []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switch from Badger to filesystem storage and back.
+	for _, filesystemEnabled := range []bool{true, false} {
+		if err := ReconcileFormat(dir, filesystemEnabled); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if contains(readEntryNames(t, dir), "data") {
+		t.Fatal("tag cache was not wiped")
+	}
+	got, err := NewTagHistory(dir).FirstSeen(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got["v1.0.0"].Equal(firstSeen) {
+		t.Fatalf("FirstSeen() got %#v, want v1.0.0 at %s", got, firstSeen)
+	}
+}
+
 func readEntryNames(t *testing.T, dir string) []string {
 	t.Helper()
 	entries, err := os.ReadDir(dir)
@@ -33,8 +33,8 @@ import (
 	imagev1 "github.com/fluxcd/image-reflector-controller/api/v1"
 )
 
-// FilesystemGarbageCollector removes tag files for ImageRepository objects no
-// longer handled by this controller instance.
+// FilesystemGarbageCollector removes tag files and tag history for
+// ImageRepository objects no longer handled by this controller instance.
 type FilesystemGarbageCollector struct {
 	Interval time.Duration
 
@@ -103,6 +103,9 @@ func (gc *FilesystemGarbageCollector) collect(ctx context.Context) {
 			gc.log.Error(err, "failed to delete orphaned filesystem storage entry", "repository", repo.String())
 			continue
 		}
+		if err := NewTagHistory(gc.storage.BasePath).Delete(RepoIdentity{Namespace: repo.Namespace, Name: repo.Name}); err != nil {
+			gc.log.Error(err, "failed to delete orphaned tag history", "repository", repo.String())
+		}
 		deleted++
 		gc.log.V(1).Info("deleted orphaned filesystem storage entry", "repository", repo.String(), "path", deletedDir)
 	}
@@ -57,6 +57,31 @@ func TestFilesystemGarbageCollectorDeletesOrphans(t *testing.T) {
 	}
 }
 
+func TestFilesystemGarbageCollectorDeletesOrphanedTagHistory(t *testing.T) {
+	st, cl := newGCTestStorage(t, &imagev1.ImageRepository{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "keep"}})
+	history := NewTagHistory(st.BasePath)
+	for _, name := range []string{"keep", "orphan"} {
+		createRepoDir(t, st, "default", name)
+		if err := history.Record(RepoIdentity{Namespace: "default", Name: name}, []string{"latest"}, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gc := NewFilesystemGarbageCollector("test-gc", st, cl, time.Minute)
+	gc.log = testr.New(t)
+	gc.collect(context.Background())
+
+	for name, want := range map[string]int{"keep": 1, "orphan": 0} {
+		got, err := history.FirstSeen(RepoIdentity{Namespace: "default", Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != want {
+			t.Fatalf("tag history of %s got %d entries, want %d", name, len(got), want)
+		}
+	}
+}
+
 func TestFilesystemGarbageCollectorSkipsDeleteOnListError(t *testing.T) {
 	st, cl := newGCTestStorage(t)
 	createRepoDir(t, st, "default", "orphan")
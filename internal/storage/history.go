@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// tagHistoryDir is the directory under the storage root holding the tag
+// history. Unlike the tag cache, the history can't be rebuilt from the
+// registry, so it is kept by ReconcileFormat when switching backends.
+const tagHistoryDir = "tag-history"
+
+// TagHistory records the time each tag of an ImageRepository was first
+// observed. The history is stored as one JSON file per ImageRepository,
+// keyed by Namespace and Name, in a backend independent format.
+type TagHistory struct {
+	basePath string
+}
+
+// NewTagHistory creates a TagHistory under the given storage root.
+func NewTagHistory(storagePath string) *TagHistory {
+	return &TagHistory{basePath: filepath.Join(storagePath, tagHistoryDir)}
+}
+
+// FirstSeen returns the time each tag of the repo was first observed. If no
+// history is recorded for the repo, an empty map is returned.
+func (h *TagHistory) FirstSeen(repo RepoIdentity) (map[string]time.Time, error) {
+	if err := validateRepoIdentity(repo); err != nil {
+		return nil, err
+	}
+
+	history := map[string]time.Time{}
+	b, err := os.ReadFile(h.path(repo))
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag history: %w", err)
+	}
+	if err := json.Unmarshal(b, &history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tag history: %w", err)
+	}
+	return history, nil
+}
+
+// Record updates the history of the repo with the given set of tags. Tags
+// observed for the first time are recorded with the given time, and tags no
+// longer present are forgotten, so a tag which is pushed again after being
+// deleted is considered new.
+func (h *TagHistory) Record(repo RepoIdentity, tags []string, now time.Time) error {
+	history, err := h.FirstSeen(repo)
+	if err != nil {
+		return err
+	}
+
+	updated := make(map[string]time.Time, len(tags))
+	for _, tag := range tags {
+		if t, ok := history[tag]; ok {
+			updated[tag] = t
+			continue
+		}
+		updated[tag] = now.UTC()
+	}
+	if maps.EqualFunc(history, updated, time.Time.Equal) {
+		return nil
+	}
+
+	b, err := json.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tag history: %w", err)
+	}
+	dir := filepath.Dir(h.path(repo))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create tag history directory: %w", err)
+	}
+	if err := writeFileSync(dir, filepath.Base(h.path(repo)), b, 0o600); err != nil {
+		return fmt.Errorf("failed to write tag history: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the history of the repo.
+func (h *TagHistory) Delete(repo RepoIdentity) error {
+	if err := validateRepoIdentity(repo); err != nil {
+		return err
+	}
+	if err := os.Remove(h.path(repo)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete tag history: %w", err)
+	}
+	return nil
+}
+
+func (h *TagHistory) path(repo RepoIdentity) string {
+	return filepath.Join(h.basePath, repo.Namespace, repo.Name+".json")
+}
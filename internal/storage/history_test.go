@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Flux authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTagHistoryRecord(t *testing.T) {
+	history := NewTagHistory(t.TempDir())
+	repo := testRepoIdentity("default", "podinfo")
+	t1 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	if err := history.Record(repo, []string{"v1.0.0", "v1.1.0"}, t1); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Record(repo, []string{"v1.0.0", "v1.1.0", "v1.2.0"}, t2); err != nil {
+		t.Fatal(err)
+	}
+	// v1.0.0 was deleted and pushed again.
+	if err := history.Record(repo, []string{"v1.1.0", "v1.2.0"}, t3); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Record(repo, []string{"v1.0.0", "v1.1.0", "v1.2.0"}, t3); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := history.FirstSeen(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]time.Time{"v1.0.0": t3, "v1.1.0": t1, "v1.2.0": t2}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FirstSeen() got %#v, want %#v", got, want)
+	}
+}
+
+func TestTagHistoryMissingAndDelete(t *testing.T) {
+	history := NewTagHistory(t.TempDir())
+	repo := testRepoIdentity("default", "podinfo")
+
+	got, err := history.FirstSeen(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("FirstSeen() for unknown repo got %#v, want empty", got)
+	}
+
+	if err := history.Record(repo, []string{"latest"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Delete(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Delete(repo); err != nil {
+		t.Fatal(err)
+	}
+	got, err = history.FirstSeen(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("FirstSeen() after delete got %#v, want empty", got)
+	}
+}
+
+func TestTagHistoryRequiresNamespaceAndName(t *testing.T) {
+	history := NewTagHistory(t.TempDir())
+	if err := history.Record(RepoIdentity{CanonicalName: "ghcr.io/stefanprodan/podinfo"}, []string{"latest"}, time.Now()); err == nil {
+		t.Fatal("Record() without namespace and name returned no error")
+	}
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package storage
 
-import "context"
+import (
+	"context"
+	"maps"
+	"time"
+)
 
 // RepoIdentity identifies an ImageRepository for tag storage. Implementations
 // choose which fields form their storage key:
@@ -28,10 +32,12 @@ type RepoIdentity struct {
 	CanonicalName string
 }
 
-// Database combines tag read and write operations.
+// Database combines tag read and write operations, and the image metadata
+// cache.
 type Database interface {
 	DatabaseWriter
 	DatabaseReader
+	MetadataCache
 }
 
 // DatabaseWriter implementations record the tags for an image repository.
@@ -41,10 +47,68 @@ type DatabaseWriter interface {
 }
 
 // DatabaseReader implementations get the stored set of tags for an image
-// repository.
+// repository, and the time each of them was first observed.
 //
 // If no tags are available for the repo, then implementations should return an
 // empty set of tags.
 type DatabaseReader interface {
 	Tags(ctx context.Context, repo RepoIdentity) (tags []string, err error)
+	FirstSeen(ctx context.Context, repo RepoIdentity) (map[string]time.Time, error)
+}
+
+// ImageMetadata holds the metadata of an image manifest which is expensive to
+// fetch from the registry.
+type ImageMetadata struct {
+	// Created is the creation time of the image.
+	Created time.Time `json:"created"`
+}
+
+// RepoMetadata holds the image metadata recorded for an image repository.
+type RepoMetadata struct {
+	// TagDigests maps the tags resolved since the tags of the repository
+	// were last recorded to the manifest digest they point to.
+	TagDigests map[string]string `json:"tagDigests,omitempty"`
+	// Images maps manifest digests to the metadata of the image.
+	Images map[string]ImageMetadata `json:"images,omitempty"`
+}
+
+// Merge adds the tag digests and image metadata of other to m.
+func (m *RepoMetadata) Merge(other RepoMetadata) {
+	if len(other.TagDigests) > 0 && m.TagDigests == nil {
+		m.TagDigests = make(map[string]string, len(other.TagDigests))
+	}
+	maps.Copy(m.TagDigests, other.TagDigests)
+	if len(other.Images) > 0 && m.Images == nil {
+		m.Images = make(map[string]ImageMetadata, len(other.Images))
+	}
+	maps.Copy(m.Images, other.Images)
+}
+
+// Reset forgets the tag digests, which may be outdated once the tags are
+// recorded again, and the metadata of the images none of them point to.
+// It returns false if nothing was recorded.
+func (m *RepoMetadata) Reset() bool {
+	if len(m.TagDigests) == 0 && len(m.Images) == 0 {
+		return false
+	}
+	referenced := make(map[string]bool, len(m.TagDigests))
+	for _, digest := range m.TagDigests {
+		referenced[digest] = true
+	}
+	maps.DeleteFunc(m.Images, func(digest string, _ ImageMetadata) bool { return !referenced[digest] })
+	m.TagDigests = nil
+	return true
+}
+
+// MetadataCache implementations record the manifest digest each tag was
+// resolved to and the metadata of these images, so that they are fetched
+// from the registry only once per scan of the repository.
+//
+// SetMetadata merges the given metadata into the recorded one. Recording
+// the tags of the repository with SetTags resets the metadata, so that the
+// tags are resolved again and the metadata of the images which are not
+// referenced anymore is pruned.
+type MetadataCache interface {
+	Metadata(ctx context.Context, repo RepoIdentity) (RepoMetadata, error)
+	SetMetadata(ctx context.Context, repo RepoIdentity, metadata RepoMetadata) error
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/dgraph-io/badger/v4"
 	flag "github.com/spf13/pflag"
@@ -319,6 +320,7 @@ func main() {
 		EventRecorder:             eventRecorder,
 		Metrics:                   metricsH,
 		Database:                  db,
+		MetadataCache:             db,
 		ACLOptions:                aclOptions,
 		ControllerName:            controllerName,
 		AuthOptionsGetter:         authOptionsGetter,